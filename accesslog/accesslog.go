@@ -0,0 +1,347 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package accesslog
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-server/authentication"
+	"github.com/euskadi31/go-server/internal/reqinfo"
+	"github.com/euskadi31/go-server/internal/route"
+	"github.com/euskadi31/go-server/requestid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/zenazn/goji/web/mutil"
+)
+
+// Format of the access log.
+type Format int
+
+// Format values.
+const (
+	// FormatJSON logs the fields with the zerolog logger.
+	FormatJSON Format = iota
+	// FormatCombined writes the Apache combined log format lines, the fields are ignored.
+	FormatCombined
+	// FormatLogfmt writes the fields as key=value lines.
+	FormatLogfmt
+)
+
+// Field of the access log.
+type Field string
+
+// Field values.
+const (
+	FieldMethod     Field = "method"
+	FieldPath       Field = "path"
+	FieldRoute      Field = "route"
+	FieldProtocol   Field = "protocol"
+	FieldStatus     Field = "status"
+	FieldLatency    Field = "latency"
+	FieldBytes      Field = "bytes"
+	FieldRemoteAddr Field = "remote_addr"
+	FieldUserAgent  Field = "user_agent"
+	FieldReferer    Field = "referer"
+	FieldPrincipal  Field = "principal"
+	FieldRequestID  Field = "request_id"
+)
+
+// DefaultFields logged when Configuration.Fields is empty.
+var DefaultFields = []Field{
+	FieldMethod,
+	FieldPath,
+	FieldRoute,
+	FieldProtocol,
+	FieldStatus,
+	FieldLatency,
+	FieldBytes,
+	FieldRemoteAddr,
+	FieldUserAgent,
+	FieldReferer,
+	FieldPrincipal,
+	FieldRequestID,
+}
+
+// DefaultRedactedHeaders are the headers never logged in clear text.
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-CSRF-Token",
+}
+
+// DefaultRedactedQueryParams are the query parameters never logged in clear text.
+var DefaultRedactedQueryParams = []string{
+	"access_token",
+	"api_key",
+	"apikey",
+	"client_secret",
+	"code",
+	"password",
+	"secret",
+	"signature",
+	"token",
+}
+
+// Redacted replaces the values of the sensitive headers and query parameters.
+const Redacted = "[REDACTED]"
+
+// Configuration struct.
+type Configuration struct {
+	Format Format
+	// Logger used by FormatJSON, the global logger is used when nil.
+	Logger *zerolog.Logger
+	// Output of the FormatCombined and FormatLogfmt lines, os.Stdout is used when nil.
+	Output io.Writer
+	// Fields logged by FormatJSON and FormatLogfmt, DefaultFields are used when empty.
+	Fields []Field
+	// Headers logged as "header.{name}" fields.
+	Headers []string
+	// RedactedHeaders are replaced by Redacted, DefaultRedactedHeaders are used when nil.
+	RedactedHeaders []string
+	// RedactedQueryParams are replaced by Redacted in the path and the referer,
+	// DefaultRedactedQueryParams are used when nil.
+	RedactedQueryParams []string
+	// Sampler of the successful requests (status < 400), all the requests are logged when nil.
+	// The errors and the slow requests are always logged.
+	Sampler zerolog.Sampler
+	// SlowThreshold is the latency above which a request is slow, disabled when zero.
+	SlowThreshold time.Duration
+
+	now func() time.Time
+}
+
+// entry of the access log.
+type entry struct {
+	start      time.Time
+	latency    time.Duration
+	method     string
+	path       string
+	route      string
+	protocol   string
+	status     int
+	bytes      int
+	remoteAddr string
+	userAgent  string
+	referer    string
+	principal  string
+	requestID  string
+	headers    [][2]string
+	slow       bool
+}
+
+// Handler logs the requests once they are served.
+func Handler(cfg *Configuration) func(next http.Handler) http.Handler {
+	if cfg.Logger == nil {
+		cfg.Logger = &log.Logger
+	}
+
+	if cfg.Output == nil {
+		cfg.Output = os.Stdout
+	}
+
+	if len(cfg.Fields) == 0 {
+		cfg.Fields = DefaultFields
+	}
+
+	if cfg.RedactedHeaders == nil {
+		cfg.RedactedHeaders = DefaultRedactedHeaders
+	}
+
+	if cfg.RedactedQueryParams == nil {
+		cfg.RedactedQueryParams = DefaultRedactedQueryParams
+	}
+
+	if cfg.now == nil {
+		cfg.now = time.Now
+	}
+
+	l := &accessLogger{
+		cfg:           cfg,
+		redactHeaders: newNameSet(cfg.RedactedHeaders, http.CanonicalHeaderKey),
+		redactParams:  newNameSet(cfg.RedactedQueryParams, lowerCase),
+	}
+
+	return l.handler
+}
+
+type accessLogger struct {
+	cfg           *Configuration
+	redactHeaders nameSet
+	redactParams  nameSet
+	mtx           sync.Mutex
+}
+
+func (l *accessLogger) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := l.cfg.now()
+
+		ctx, info := reqinfo.ToContext(r.Context())
+		r = r.WithContext(ctx)
+
+		lw := mutil.WrapWriter(w)
+
+		next.ServeHTTP(lw, r)
+
+		e := l.entry(r, info, lw, start)
+
+		if !l.sampled(e) {
+			return
+		}
+
+		l.write(e)
+	})
+}
+
+func (l *accessLogger) entry(r *http.Request, info *reqinfo.Info, lw mutil.WriterProxy, start time.Time) *entry {
+	e := &entry{
+		start:      start,
+		latency:    l.cfg.now().Sub(start),
+		method:     r.Method,
+		path:       l.redactURI(r.URL.RequestURI()),
+		protocol:   r.Proto,
+		status:     lw.Status(),
+		bytes:      lw.BytesWritten(),
+		remoteAddr: r.RemoteAddr,
+		userAgent:  r.UserAgent(),
+		referer:    l.redactURI(r.Referer()),
+		principal:  info.Principal(),
+	}
+
+	if e.status == 0 {
+		e.status = http.StatusOK
+	}
+
+	if tpl, ok := route.Template(r); ok {
+		e.route = tpl
+	}
+
+	if e.principal == "" {
+		e.principal = authentication.SubjectFromContext(r.Context())
+	}
+
+	// The request ID handler may run after the access log.
+	if id, ok := requestid.FromContext(r.Context()); ok {
+		e.requestID = id
+	} else {
+		e.requestID = info.RequestID()
+	}
+
+	for _, name := range l.cfg.Headers {
+		value := r.Header.Get(name)
+		if value == "" {
+			continue
+		}
+
+		if l.redactHeaders.has(http.CanonicalHeaderKey(name)) {
+			value = Redacted
+		}
+
+		e.headers = append(e.headers, [2]string{lowerCase(name), value})
+	}
+
+	e.slow = l.cfg.SlowThreshold > 0 && e.latency >= l.cfg.SlowThreshold
+
+	return e
+}
+
+// sampled reports whether the entry is logged, the errors and the slow requests are always logged.
+func (l *accessLogger) sampled(e *entry) bool {
+	if l.cfg.Sampler == nil || e.status >= http.StatusBadRequest || e.slow {
+		return true
+	}
+
+	return l.cfg.Sampler.Sample(zerolog.InfoLevel)
+}
+
+func (e *entry) level() zerolog.Level {
+	switch {
+	case e.status >= http.StatusInternalServerError:
+		return zerolog.ErrorLevel
+	case e.status >= http.StatusBadRequest, e.slow:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
+
+func (l *accessLogger) write(e *entry) {
+	switch l.cfg.Format {
+	case FormatCombined:
+		l.writeLine(formatCombined(e))
+	case FormatLogfmt:
+		l.writeLine(formatLogfmt(e, l.cfg.Fields))
+	default:
+		l.logJSON(e)
+	}
+}
+
+func (l *accessLogger) writeLine(line []byte) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if _, err := l.cfg.Output.Write(line); err != nil {
+		log.Error().Err(err).Msg("Write access log failed")
+	}
+}
+
+func (l *accessLogger) logJSON(e *entry) {
+	event := l.cfg.Logger.WithLevel(e.level())
+
+	for _, field := range l.cfg.Fields {
+		switch field {
+		case FieldMethod:
+			event.Str(string(field), e.method)
+		case FieldPath:
+			event.Str(string(field), e.path)
+		case FieldRoute:
+			if e.route != "" {
+				event.Str(string(field), e.route)
+			}
+		case FieldProtocol:
+			event.Str(string(field), e.protocol)
+		case FieldStatus:
+			event.Int(string(field), e.status)
+		case FieldLatency:
+			event.Dur(string(field), e.latency)
+		case FieldBytes:
+			event.Int(string(field), e.bytes)
+		case FieldRemoteAddr:
+			event.Str(string(field), e.remoteAddr)
+		case FieldUserAgent:
+			if e.userAgent != "" {
+				event.Str(string(field), e.userAgent)
+			}
+		case FieldReferer:
+			if e.referer != "" {
+				event.Str(string(field), e.referer)
+			}
+		case FieldPrincipal:
+			if e.principal != "" {
+				event.Str(string(field), e.principal)
+			}
+		case FieldRequestID:
+			if e.requestID != "" {
+				event.Str(string(field), e.requestID)
+			}
+		}
+	}
+
+	for _, header := range e.headers {
+		event.Str("header."+header[0], header[1])
+	}
+
+	if e.slow {
+		event.Bool("slow", true)
+	}
+
+	event.Msg("Request served")
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-server/authentication"
+	"github.com/euskadi31/go-server/requestid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type neverSampler struct{}
+
+func (neverSampler) Sample(lvl zerolog.Level) bool {
+	return false
+}
+
+func fixedClock(latency time.Duration) func() time.Time {
+	start := time.Date(2026, time.October, 10, 13, 55, 36, 0, time.UTC)
+	calls := 0
+
+	return func() time.Time {
+		calls++
+
+		if calls%2 == 0 {
+			return start.Add(latency)
+		}
+
+		return start
+	}
+}
+
+func serve(t *testing.T, cfg *Configuration, req *http.Request, status int) {
+	t.Helper()
+
+	r := mux.NewRouter()
+	r.Use(requestid.Handler(&requestid.Configuration{}))
+	r.Use(Handler(cfg))
+	r.Use(authentication.Handler(&authentication.Configuration{}, authentication.ProviderFunc(func(r *http.Request) (*authentication.Principal, error) {
+		return &authentication.Principal{Subject: "alice"}, nil
+	})))
+	r.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("hello"))
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func newRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/users/42?token=s3cr3t&page=2", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("User-Agent", "test/1.0")
+	req.Header.Set("Referer", "http://example.com/?password=foo")
+	req.Header.Set("X-Request-ID", "abc-123")
+	req.Header.Set("Authorization", "Bearer foo")
+	req.Header.Set("Accept", "application/json")
+
+	return req
+}
+
+func TestHandlerJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	serve(t, &Configuration{
+		Logger:  &logger,
+		Headers: []string{"Authorization", "Accept", "X-Missing"},
+		now:     fixedClock(15 * time.Millisecond),
+	}, newRequest(), http.StatusOK)
+
+	entry := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, map[string]interface{}{
+		"level":                "info",
+		"method":               "GET",
+		"path":                 "/users/42?token=[REDACTED]&page=2",
+		"route":                "/users/{id}",
+		"protocol":             "HTTP/1.1",
+		"status":               float64(200),
+		"latency":              float64(15),
+		"bytes":                float64(5),
+		"remote_addr":          "192.0.2.1:1234",
+		"user_agent":           "test/1.0",
+		"referer":              "http://example.com/?password=[REDACTED]",
+		"principal":            "alice",
+		"request_id":           "abc-123",
+		"header.authorization": "[REDACTED]",
+		"header.accept":        "application/json",
+		"message":              "Request served",
+	}, entry)
+}
+
+func TestHandlerCombined(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	serve(t, &Configuration{
+		Format: FormatCombined,
+		Output: buf,
+		now:    fixedClock(time.Millisecond),
+	}, newRequest(), http.StatusOK)
+
+	assert.Equal(
+		t,
+		`192.0.2.1 - alice [10/Oct/2026:13:55:36 +0000] "GET /users/42?token=[REDACTED]&page=2 HTTP/1.1" 200 5 "http://example.com/?password=[REDACTED]" "test/1.0"`+"\n",
+		buf.String(),
+	)
+}
+
+func TestHandlerLogfmt(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	serve(t, &Configuration{
+		Format: FormatLogfmt,
+		Output: buf,
+		Fields: []Field{FieldMethod, FieldRoute, FieldStatus, FieldLatency, FieldUserAgent},
+		now:    fixedClock(1500 * time.Millisecond),
+	}, newRequest(), http.StatusBadGateway)
+
+	assert.Equal(
+		t,
+		`time=2026-10-10T13:55:36Z level=error method=GET route=/users/{id} status=502 latency=1.5s user_agent=test/1.0`+"\n",
+		buf.String(),
+	)
+}
+
+func TestHandlerSampling(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	cfg := &Configuration{
+		Format:        FormatLogfmt,
+		Output:        buf,
+		Fields:        []Field{FieldStatus},
+		Sampler:       neverSampler{},
+		SlowThreshold: time.Second,
+		now:           fixedClock(10 * time.Millisecond),
+	}
+
+	serve(t, cfg, newRequest(), http.StatusOK)
+	assert.Empty(t, buf.String(), "the successful requests are sampled")
+
+	serve(t, cfg, newRequest(), http.StatusNotFound)
+	assert.Equal(t, "time=2026-10-10T13:55:36Z level=warn status=404\n", buf.String(), "the errors are always logged")
+
+	buf.Reset()
+	cfg.now = fixedClock(2 * time.Second)
+
+	serve(t, cfg, newRequest(), http.StatusOK)
+	assert.Equal(t, "time=2026-10-10T13:55:36Z level=warn status=200 slow=true\n", buf.String(), "the slow requests are always logged")
+}
+
+func TestHandlerWithoutRoute(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	h := Handler(&Configuration{
+		Logger: &logger,
+		Fields: []Field{FieldRoute, FieldStatus, FieldPrincipal, FieldRequestID},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.JSONEq(t, `{"level":"info","status":200,"message":"Request served"}`, buf.String())
+}
+
+func TestHandlerBeforeRequestID(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	r := mux.NewRouter()
+	r.Use(Handler(&Configuration{
+		Logger: &logger,
+		Fields: []Field{FieldRequestID},
+	}))
+	r.Use(requestid.Handler(&requestid.Configuration{}))
+	r.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	r.ServeHTTP(httptest.NewRecorder(), newRequest())
+
+	assert.JSONEq(t, `{"level":"info","request_id":"abc-123","message":"Request served"}`, buf.String())
+}
+
+func TestAppendPair(t *testing.T) {
+	assert.Equal(t, "a=b ", string(appendPair(nil, "a", "b")))
+	assert.Equal(t, `a="b c" `, string(appendPair(nil, "a", "b c")))
+	assert.Equal(t, `a="" `, string(appendPair(nil, "a", "")))
+	assert.Equal(t, `a="b\nc" `, string(appendPair(nil, "a", "b\nc")))
+}
+
+func TestRedactURI(t *testing.T) {
+	l := &accessLogger{
+		redactParams: newNameSet([]string{"Token"}, lowerCase),
+	}
+
+	assert.Equal(t, "/foo", l.redactURI("/foo"))
+	assert.Equal(t, "/foo?TOKEN=[REDACTED]&a=1&b", l.redactURI("/foo?TOKEN=bar&a=1&b"))
+	assert.True(t, strings.HasSuffix(l.redactURI("/foo?%74oken=bar"), "=[REDACTED]"))
+}
+
+func TestHostOf(t *testing.T) {
+	assert.Equal(t, "192.0.2.1", hostOf("192.0.2.1:1234"))
+	assert.Equal(t, "::1", hostOf("[::1]:80"))
+	assert.Equal(t, "192.0.2.1", hostOf("192.0.2.1"))
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package accesslog
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const combinedTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// formatCombined returns the Apache combined log format line of the entry:
+// %h %l %u %t "%r" %>s %b "%{Referer}i" "%{User-agent}i".
+func formatCombined(e *entry) []byte {
+	b := make([]byte, 0, 256)
+
+	b = append(b, dash(hostOf(e.remoteAddr))...)
+	b = append(b, " - "...)
+	b = append(b, dash(e.principal)...)
+	b = append(b, " ["...)
+	b = e.start.AppendFormat(b, combinedTimeLayout)
+	b = append(b, `] "`...)
+	b = appendEscaped(b, e.method+" "+e.path+" "+e.protocol)
+	b = append(b, `" `...)
+	b = strconv.AppendInt(b, int64(e.status), 10)
+	b = append(b, ' ')
+
+	if e.bytes > 0 {
+		b = strconv.AppendInt(b, int64(e.bytes), 10)
+	} else {
+		b = append(b, '-')
+	}
+
+	b = append(b, ` "`...)
+	b = appendEscaped(b, dash(e.referer))
+	b = append(b, `" "`...)
+	b = appendEscaped(b, dash(e.userAgent))
+	b = append(b, "\"\n"...)
+
+	return b
+}
+
+// formatLogfmt returns the logfmt line of the entry with the selected fields.
+func formatLogfmt(e *entry, fields []Field) []byte {
+	b := make([]byte, 0, 256)
+
+	b = appendPair(b, "time", e.start.Format(time.RFC3339))
+	b = appendPair(b, "level", e.level().String())
+
+	for _, field := range fields {
+		var value string
+
+		switch field {
+		case FieldMethod:
+			value = e.method
+		case FieldPath:
+			value = e.path
+		case FieldRoute:
+			value = e.route
+		case FieldProtocol:
+			value = e.protocol
+		case FieldStatus:
+			value = strconv.Itoa(e.status)
+		case FieldLatency:
+			value = e.latency.String()
+		case FieldBytes:
+			value = strconv.Itoa(e.bytes)
+		case FieldRemoteAddr:
+			value = e.remoteAddr
+		case FieldUserAgent:
+			value = e.userAgent
+		case FieldReferer:
+			value = e.referer
+		case FieldPrincipal:
+			value = e.principal
+		case FieldRequestID:
+			value = e.requestID
+		}
+
+		if value != "" {
+			b = appendPair(b, string(field), value)
+		}
+	}
+
+	for _, header := range e.headers {
+		b = appendPair(b, "header."+header[0], header[1])
+	}
+
+	if e.slow {
+		b = appendPair(b, "slow", "true")
+	}
+
+	b[len(b)-1] = '\n'
+
+	return b
+}
+
+// appendPair appends key=value followed by a space, the value is quoted when needed.
+func appendPair(b []byte, key string, value string) []byte {
+	b = append(b, key...)
+	b = append(b, '=')
+
+	if strings.ContainsAny(value, " =\"\\") || value == "" || !isPrintable(value) {
+		b = strconv.AppendQuote(b, value)
+	} else {
+		b = append(b, value...)
+	}
+
+	return append(b, ' ')
+}
+
+// appendEscaped appends the value with the quotes, backslashes and control characters escaped.
+func appendEscaped(b []byte, value string) []byte {
+	quoted := strconv.Quote(value)
+
+	return append(b, quoted[1:len(quoted)-1]...)
+}
+
+func isPrintable(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < 0x20 || value[i] == 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+// hostOf returns the host of a host:port address.
+func hostOf(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return addr
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package accesslog
+
+import (
+	"net/url"
+	"strings"
+)
+
+type nameSet map[string]struct{}
+
+func newNameSet(names []string, normalize func(string) string) nameSet {
+	set := make(nameSet, len(names))
+
+	for _, name := range names {
+		set[normalize(name)] = struct{}{}
+	}
+
+	return set
+}
+
+func (s nameSet) has(name string) bool {
+	_, ok := s[name]
+
+	return ok
+}
+
+func lowerCase(s string) string {
+	return strings.ToLower(s)
+}
+
+// redactURI replaces the values of the sensitive query parameters of the URI,
+// the order of the parameters is preserved.
+func (l *accessLogger) redactURI(uri string) string {
+	i := strings.IndexByte(uri, '?')
+	if i < 0 || len(l.redactParams) == 0 {
+		return uri
+	}
+
+	params := strings.Split(uri[i+1:], "&")
+
+	for j, param := range params {
+		name, _, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+
+		decoded, err := url.QueryUnescape(name)
+		if err != nil {
+			decoded = name
+		}
+
+		if l.redactParams.has(lowerCase(decoded)) {
+			params[j] = name + "=" + Redacted
+		}
+	}
+
+	return uri[:i+1] + strings.Join(params, "&")
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchemeAPIKey is the challenge scheme of the API key authentication.
+const SchemeAPIKey = "APIKey"
+
+// DefaultAPIKeyHeader is the request header carrying the API key.
+const DefaultAPIKeyHeader = "X-API-Key"
+
+// APIKeyStore interface.
+type APIKeyStore interface {
+	// Verify returns the Principal of the API key.
+	Verify(key string) (*Principal, error)
+}
+
+// APIKeyConfiguration struct.
+type APIKeyConfiguration struct {
+	// Header carrying the API key, DefaultAPIKeyHeader is used when empty.
+	Header string
+	// QueryParam carrying the API key, disabled when empty.
+	QueryParam string
+}
+
+// APIKey provider authenticates the API key of the request header or query param with the store.
+func APIKey(cfg *APIKeyConfiguration, store APIKeyStore) Provider {
+	if cfg.Header == "" {
+		cfg.Header = DefaultAPIKeyHeader
+	}
+
+	return Named("api_key", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		key := r.Header.Get(cfg.Header)
+
+		if key == "" && cfg.QueryParam != "" {
+			key = r.URL.Query().Get(cfg.QueryParam)
+		}
+
+		if key == "" {
+			return nil, &Error{
+				Scheme: SchemeAPIKey,
+				Err:    ErrNoCredentials,
+			}
+		}
+
+		principal, err := store.Verify(key)
+		if err != nil {
+			return nil, &Error{
+				Scheme: SchemeAPIKey,
+				Err:    err,
+			}
+		}
+
+		return principal, nil
+	}))
+}
+
+// GenerateAPIKey returns a new "id.secret" API key and the APIKeyFile line to store it.
+func GenerateAPIKey(id string, scopes []string, expiresAt time.Time) (key string, line string, err error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	secret := base64.RawURLEncoding.EncodeToString(b)
+
+	hash, err := HashSHA256(secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	expiry := ""
+	if !expiresAt.IsZero() {
+		expiry = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return id + "." + secret, strings.Join([]string{id, hash, strings.Join(scopes, ","), expiry}, ":"), nil
+}
+
+type apiKeyEntry struct {
+	hash      string
+	scopes    []string
+	expiresAt time.Time
+}
+
+// APIKeyFile is an APIKeyStore backed by a file of "id:hash:scope1,scope2:expiry" lines,
+// the API keys have the "id.secret" form and only the salted hash of the secret is stored.
+// The expiry is an optional RFC 3339 date. The file is reloaded when it changes.
+type APIKeyFile struct {
+	file *reloadableFile
+	mtx  sync.RWMutex
+	keys map[string]apiKeyEntry
+	now  func() time.Time
+}
+
+// NewAPIKeyFile constructor.
+func NewAPIKeyFile(filename string) (*APIKeyFile, error) {
+	f := &APIKeyFile{
+		keys: make(map[string]apiKeyEntry),
+		now:  time.Now,
+	}
+
+	f.file = &reloadableFile{
+		filename: filename,
+		interval: DefaultReloadInterval,
+		parse:    f.parse,
+	}
+
+	if err := f.file.load(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *APIKeyFile) parse(r io.Reader) error {
+	keys := make(map[string]apiKeyEntry)
+
+	if err := scanLines(r, func(line string, n int) error {
+		// The hash contains "$" separators but no ":".
+		parts := strings.Split(line, ":")
+		if len(parts) < 2 || parts[0] == "" {
+			return fmt.Errorf("invalid api key line %d", n)
+		}
+
+		if err := validateHash(parts[1]); err != nil {
+			return fmt.Errorf("invalid api key hash line %d: %w", n, err)
+		}
+
+		entry := apiKeyEntry{
+			hash: parts[1],
+		}
+
+		if len(parts) > 2 && parts[2] != "" {
+			entry.scopes = strings.Split(parts[2], ",")
+		}
+
+		if len(parts) > 3 && parts[3] != "" {
+			expiresAt, err := time.Parse(time.RFC3339, strings.Join(parts[3:], ":"))
+			if err != nil {
+				return fmt.Errorf("invalid api key expiry line %d: %w", n, err)
+			}
+
+			entry.expiresAt = expiresAt
+		}
+
+		keys[parts[0]] = entry
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	f.mtx.Lock()
+	f.keys = keys
+	f.mtx.Unlock()
+
+	return nil
+}
+
+// Verify implements APIKeyStore.
+func (f *APIKeyFile) Verify(key string) (*Principal, error) {
+	f.file.reloadIfChanged()
+
+	id, secret, ok := strings.Cut(key, ".")
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	f.mtx.RLock()
+	entry, exists := f.keys[id]
+	f.mtx.RUnlock()
+
+	if !exists {
+		// Compare anyway, to not leak the key IDs by timing.
+		entry.hash = encodeSHA256(make([]byte, saltLen), "")
+	}
+
+	valid, err := VerifyHash(entry.hash, secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	if !valid || !exists {
+		return nil, ErrInvalidCredentials
+	}
+
+	if !entry.expiresAt.IsZero() && f.now().After(entry.expiresAt) {
+		return nil, ErrExpiredCredentials
+	}
+
+	return &Principal{
+		Subject: id,
+		Scopes:  entry.scopes,
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	expiresAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	key, line, err := GenerateAPIKey("ci", []string{"deploy", "read"}, expiresAt)
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, "ci."))
+	assert.True(t, strings.HasPrefix(line, "ci:$sha256$"))
+	assert.True(t, strings.HasSuffix(line, ":deploy,read:2030-01-01T00:00:00Z"))
+	assert.NotContains(t, line, strings.TrimPrefix(key, "ci."))
+}
+
+func TestAPIKeyFile(t *testing.T) {
+	ciKey, ciLine, err := GenerateAPIKey("ci", []string{"deploy", "read"}, time.Time{})
+	assert.NoError(t, err)
+
+	oldKey, oldLine, err := GenerateAPIKey("old", nil, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), "api-keys")
+
+	writeFile(t, filename, "# API keys\n"+ciLine+"\n"+oldLine+"\n", time.Now().Add(-time.Hour))
+
+	store, err := NewAPIKeyFile(filename)
+	assert.NoError(t, err)
+
+	principal, err := store.Verify(ciKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", principal.Subject)
+	assert.Equal(t, []string{"deploy", "read"}, principal.Scopes)
+
+	_, err = store.Verify(oldKey)
+	assert.ErrorIs(t, err, ErrExpiredCredentials)
+
+	_, err = store.Verify("ci.bad")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = store.Verify("unknown.secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = store.Verify("malformed")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	newKey, newLine, err := GenerateAPIKey("new", nil, time.Time{})
+	assert.NoError(t, err)
+
+	store.file.interval = 0
+
+	writeFile(t, filename, ciLine+"\n"+newLine+"\n", time.Now())
+
+	_, err = store.Verify(newKey)
+	assert.NoError(t, err)
+}
+
+func TestNewAPIKeyFileWithBadFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "api-keys")
+
+	writeFile(t, filename, "ci:$sha256$c2FsdA$aGFzaA::yesterday\n", time.Now())
+
+	_, err := NewAPIKeyFile(filename)
+	assert.Error(t, err)
+
+	writeFile(t, filename, ":hash\n", time.Now())
+
+	_, err = NewAPIKeyFile(filename)
+	assert.Error(t, err)
+}
+
+func TestAPIKey(t *testing.T) {
+	key, line, err := GenerateAPIKey("ci", nil, time.Time{})
+	assert.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), "api-keys")
+
+	writeFile(t, filename, line+"\n", time.Now())
+
+	store, err := NewAPIKeyFile(filename)
+	assert.NoError(t, err)
+
+	provider := APIKey(&APIKeyConfiguration{
+		QueryParam: "api_key",
+	}, store)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	_, err = provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+	assert.Equal(t, `APIKey realm="Test"`, challengeFromError("Test", err))
+
+	req.Header.Set(DefaultAPIKeyHeader, "ci.bad")
+
+	_, err = provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	req.Header.Set(DefaultAPIKeyHeader, key)
+
+	principal, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", principal.Subject)
+
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/foo?api_key="+key, nil)
+
+	principal, err = provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", principal.Subject)
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/euskadi31/go-server/internal/route"
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// DefaultAuditBurst sets the number of failure events logged per DefaultAuditPeriod (10).
+	DefaultAuditBurst uint32 = 10
+
+	// DefaultAuditPeriod sets the period of the audit log rate limit (1s).
+	DefaultAuditPeriod = time.Second
+)
+
+// Authentication outcomes.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeDenied  = "denied"
+)
+
+// Authentication failure reasons.
+const (
+	ReasonNoCredentials          = "no_credentials"
+	ReasonInvalidCredentials     = "invalid_credentials"
+	ReasonExpiredCredentials     = "expired_credentials"
+	ReasonUnauthenticated        = "unauthenticated"
+	ReasonInsufficientPrivileges = "insufficient_privileges"
+	ReasonUnavailable            = "unavailable"
+	ReasonError                  = "error"
+)
+
+// newAuthenticationTotal registers the http_authentication_total counter in opts.Registerer,
+// the counter already registered by another configuration is reused.
+func newAuthenticationTotal(opts metrics.Options) *prometheus.CounterVec {
+	registerer := opts.Registerer
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	counter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   opts.Subsystem,
+			Name:        "http_authentication_total",
+			Help:        "The count of authentication outcomes by provider and reason.",
+			ConstLabels: opts.ConstLabels,
+		},
+		[]string{"provider", "outcome", "reason"},
+	)
+
+	if err := registerer.Register(counter); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(*prometheus.CounterVec); ok {
+				return existing
+			}
+		}
+
+		log.Debug().Err(err).Msg("prometheus register authentication total")
+	}
+
+	return counter
+}
+
+// authenticationTotal returns the counter of the outcomes, registered on first use.
+func (c *Configuration) authenticationTotal() *prometheus.CounterVec {
+	c.metricsOnce.Do(func() {
+		c.total = newAuthenticationTotal(c.Metrics)
+	})
+
+	return c.total
+}
+
+// reasonFromError returns the failure reason of a provider error.
+func reasonFromError(err error) string {
+	switch {
+	case errors.Is(err, ErrNoCredentials):
+		return ReasonNoCredentials
+	case errors.Is(err, ErrExpiredCredentials):
+		return ReasonExpiredCredentials
+	case errors.Is(err, ErrInvalidCredentials):
+		return ReasonInvalidCredentials
+	case errors.Is(err, ErrUnavailable):
+		return ReasonUnavailable
+	default:
+		return ReasonError
+	}
+}
+
+// auditLogger returns the logger of the failure events, it is rate limited
+// so a credential stuffing burst does not flood the logs.
+func (c *Configuration) auditLogger() *zerolog.Logger {
+	c.auditOnce.Do(func() {
+		sampler := c.AuditSampler
+		if sampler == nil {
+			sampler = &zerolog.BurstSampler{
+				Burst:  DefaultAuditBurst,
+				Period: DefaultAuditPeriod,
+			}
+		}
+
+		logger := log.Logger.Sample(sampler)
+
+		c.audit = &logger
+	})
+
+	return c.audit
+}
+
+// auditEvent counts the outcome and logs the audit event, successes are logged at debug level.
+func (c *Configuration) auditEvent(r *http.Request, outcome string, provider string, reason string, principal *Principal, err error) {
+	c.authenticationTotal().WithLabelValues(provider, outcome, reason).Inc()
+
+	var event *zerolog.Event
+
+	msg := "Authentication failed"
+
+	switch outcome {
+	case OutcomeSuccess:
+		event = log.Debug()
+		msg = "Authentication succeeded"
+	case OutcomeDenied:
+		event = c.auditLogger().Warn()
+		msg = "Access denied"
+	default:
+		event = c.auditLogger().Warn()
+	}
+
+	if principal != nil {
+		event = event.Str("subject", principal.Subject)
+	}
+
+	event.
+		Str("event", "authentication").
+		Str("outcome", outcome).
+		Str("provider", provider).
+		Str("reason", reason).
+		Str("route", routeTemplate(r)).
+		Str("method", r.Method).
+		Str("remote_addr", r.RemoteAddr).
+		Err(err).
+		Msg(msg)
+}
+
+// routeTemplate returns the path template of the mux route, or the path outside of a mux router.
+func routeTemplate(r *http.Request) string {
+	if tpl, ok := route.Template(r); ok {
+		return tpl
+	}
+
+	return r.URL.Path
+}
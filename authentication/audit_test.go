@@ -0,0 +1,220 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+
+	logger := log.Logger
+	log.Logger = zerolog.New(buf).Level(zerolog.DebugLevel)
+
+	t.Cleanup(func() {
+		log.Logger = logger
+	})
+
+	return buf
+}
+
+func decodeLogs(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	events := []map[string]interface{}{}
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		event := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func TestHandlerAudit(t *testing.T) {
+	buf := captureLogs(t)
+
+	provider := Named("static", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		if r.Header.Get("Authorization") == "" {
+			return nil, ErrNoCredentials
+		}
+
+		if r.Header.Get("Authorization") != "Bearer good" {
+			return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token is invalid")
+		}
+
+		return &Principal{Subject: "john"}, nil
+	}))
+
+	config := &Configuration{
+		Realm: "Test",
+	}
+
+	router := mux.NewRouter()
+	router.Use(Handler(config, provider))
+	router.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	success := testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeSuccess, ""))
+	invalid := testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeFailure, ReasonInvalidCredentials))
+	missing := testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeFailure, ReasonNoCredentials))
+
+	for _, authorization := range []string{"Bearer good", "Bearer bad", ""} {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/users/42", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("Authorization", authorization)
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, success+1, testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeSuccess, "")))
+	assert.Equal(t, invalid+1, testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeFailure, ReasonInvalidCredentials)))
+	assert.Equal(t, missing+1, testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeFailure, ReasonNoCredentials)))
+
+	events := decodeLogs(t, buf)
+	assert.Len(t, events, 3)
+
+	assert.Equal(t, "debug", events[0]["level"])
+	assert.Equal(t, "Authentication succeeded", events[0]["message"])
+	assert.Equal(t, "john", events[0]["subject"])
+	assert.Equal(t, "static", events[0]["provider"])
+
+	assert.Equal(t, "warn", events[1]["level"])
+	assert.Equal(t, "Authentication failed", events[1]["message"])
+	assert.Equal(t, "authentication", events[1]["event"])
+	assert.Equal(t, OutcomeFailure, events[1]["outcome"])
+	assert.Equal(t, ReasonInvalidCredentials, events[1]["reason"])
+	assert.Equal(t, "/users/{id}", events[1]["route"])
+	assert.Equal(t, http.MethodGet, events[1]["method"])
+	assert.Equal(t, "192.0.2.1:1234", events[1]["remote_addr"])
+	assert.Contains(t, events[1]["error"], "invalid credentials")
+	assert.NotContains(t, events[1], "subject")
+
+	assert.Equal(t, ReasonNoCredentials, events[2]["reason"])
+}
+
+func TestHandlerAuditRateLimit(t *testing.T) {
+	buf := captureLogs(t)
+
+	provider := Named("static", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		return nil, ErrInvalidCredentials
+	}))
+
+	config := &Configuration{
+		AuditSampler: &zerolog.BurstSampler{
+			Burst:  3,
+			Period: time.Hour,
+		},
+	}
+
+	before := testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeFailure, ReasonInvalidCredentials))
+
+	handler := Handler(config, provider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < 50; i++ {
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/login", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+
+	events := decodeLogs(t, buf)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "/login", events[0]["route"])
+
+	// The metrics are not sampled.
+	assert.Equal(t, before+50, testutil.ToFloat64(config.authenticationTotal().WithLabelValues("static", OutcomeFailure, ReasonInvalidCredentials)))
+}
+
+func TestAuthorizeAudit(t *testing.T) {
+	buf := captureLogs(t)
+
+	config := &Configuration{}
+
+	before := testutil.ToFloat64(config.authenticationTotal().WithLabelValues("jwt", OutcomeDenied, ReasonInsufficientPrivileges))
+
+	handler := RequireScopes(config, "admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodDelete, "http://example.com/users/42", nil)
+	req = req.WithContext(ToContext(req.Context(), &Principal{
+		Provider: "jwt",
+		Subject:  "john",
+	}))
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(config.authenticationTotal().WithLabelValues("jwt", OutcomeDenied, ReasonInsufficientPrivileges)))
+
+	events := decodeLogs(t, buf)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "Access denied", events[0]["message"])
+	assert.Equal(t, "john", events[0]["subject"])
+	assert.Equal(t, ReasonInsufficientPrivileges, events[0]["reason"])
+}
+
+func TestReasonFromError(t *testing.T) {
+	assert.Equal(t, ReasonNoCredentials, reasonFromError(ErrNoCredentials))
+	assert.Equal(t, ReasonInvalidCredentials, reasonFromError(NewInvalidTokenError(ErrInvalidCredentials, "")))
+	assert.Equal(t, ReasonExpiredCredentials, reasonFromError(&providerError{provider: "jwt", err: ErrExpiredCredentials}))
+	assert.Equal(t, ReasonError, reasonFromError(ErrKeyNotFound))
+}
+
+func TestHandlerAuditMetricsWithOptions(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	provider := Named("static", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		return nil, ErrInvalidCredentials
+	}))
+
+	handler := Handler(&Configuration{
+		Metrics: metrics.Options{
+			Namespace:  "app",
+			Subsystem:  "api",
+			Registerer: registry,
+		},
+	}, provider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/login", nil))
+
+	expected := `
+		# HELP app_api_http_authentication_total The count of authentication outcomes by provider and reason.
+		# TYPE app_api_http_authentication_total counter
+		app_api_http_authentication_total{outcome="failure",provider="static",reason="invalid_credentials"} 1
+	`
+
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "app_api_http_authentication_total"))
+
+	count, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "app_api_http_authentication_total")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count, "nothing is registered in the default registerer")
+}
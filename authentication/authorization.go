@@ -0,0 +1,120 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/euskadi31/go-server/response"
+)
+
+// PolicyFunc is the type of a function deciding if the principal is allowed to perform the request.
+type PolicyFunc func(r *http.Request, principal *Principal) bool
+
+// Requirement struct describes the authorization requirements of a route.
+type Requirement struct {
+	// Scopes must all be granted to the principal.
+	Scopes []string
+	// Roles requires at least one of the roles.
+	Roles []string
+	// Policy is evaluated after the scopes and roles when not nil.
+	Policy PolicyFunc
+}
+
+// Authorize middleware checks the Principal set by Handler against the requirement,
+// it responds 401 without Principal and 403 with an insufficient_scope challenge otherwise.
+//
+//	router.Handle("/admin", authentication.Authorize(config, authentication.Requirement{
+//		Scopes: []string{"admin"},
+//	})(handler))
+func Authorize(config *Configuration, requirement Requirement) func(http.Handler) http.Handler {
+	config.authenticationTotal()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := FromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", challenge(SchemeBearer, "realm", config.Realm))
+
+				config.auditEvent(r, OutcomeFailure, "", ReasonUnauthenticated, nil, nil)
+
+				response.FailureFromError(w, http.StatusUnauthorized, errors.New("Unauthorized"))
+
+				return
+			}
+
+			if !requirement.allows(r, principal) {
+				w.Header().Set("WWW-Authenticate", challenge(
+					SchemeBearer,
+					"realm", config.Realm,
+					"error", ErrorCodeInsufficientScope,
+					"error_description", "The request requires higher privileges than provided by the access token",
+					"scope", strings.Join(requirement.Scopes, " "),
+				))
+
+				config.auditEvent(r, OutcomeDenied, principal.Provider, ReasonInsufficientPrivileges, principal, nil)
+
+				response.FailureFromError(w, http.StatusForbidden, errors.New("Forbidden"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScopes middleware requires all the scopes.
+func RequireScopes(config *Configuration, scopes ...string) func(http.Handler) http.Handler {
+	return Authorize(config, Requirement{
+		Scopes: scopes,
+	})
+}
+
+// RequireRoles middleware requires one of the roles.
+func RequireRoles(config *Configuration, roles ...string) func(http.Handler) http.Handler {
+	return Authorize(config, Requirement{
+		Roles: roles,
+	})
+}
+
+// RequirePolicy middleware requires the policy to allow the request.
+func RequirePolicy(config *Configuration, policy PolicyFunc) func(http.Handler) http.Handler {
+	return Authorize(config, Requirement{
+		Policy: policy,
+	})
+}
+
+func (req Requirement) allows(r *http.Request, principal *Principal) bool {
+	for _, scope := range req.Scopes {
+		if !principal.HasScope(scope) {
+			return false
+		}
+	}
+
+	if len(req.Roles) > 0 {
+		allowed := false
+
+		for _, role := range req.Roles {
+			if principal.HasRole(role) {
+				allowed = true
+
+				break
+			}
+		}
+
+		if !allowed {
+			return false
+		}
+	}
+
+	if req.Policy != nil {
+		return req.Policy(r, principal)
+	}
+
+	return true
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveWithPrincipal(principal *Principal, handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+
+	if principal != nil {
+		req = req.WithContext(ToContext(req.Context(), principal))
+	}
+
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAuthorize(t *testing.T) {
+	config := &Configuration{
+		Realm: "Test",
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	john := &Principal{
+		Subject: "john",
+		Scopes:  []string{"read", "write"},
+		Roles:   []string{"editor"},
+	}
+
+	for name, item := range map[string]struct {
+		principal   *Principal
+		requirement Requirement
+		status      int
+		challenge   string
+	}{
+		"no principal": {
+			requirement: Requirement{Scopes: []string{"read"}},
+			status:      http.StatusUnauthorized,
+			challenge:   `Bearer realm="Test"`,
+		},
+		"scopes granted": {
+			principal:   john,
+			requirement: Requirement{Scopes: []string{"read", "write"}},
+			status:      http.StatusOK,
+		},
+		"scope missing": {
+			principal:   john,
+			requirement: Requirement{Scopes: []string{"read", "admin"}},
+			status:      http.StatusForbidden,
+			challenge:   `Bearer realm="Test", error="insufficient_scope", error_description="The request requires higher privileges than provided by the access token", scope="read admin"`,
+		},
+		"one of roles": {
+			principal:   john,
+			requirement: Requirement{Roles: []string{"admin", "editor"}},
+			status:      http.StatusOK,
+		},
+		"role missing": {
+			principal:   john,
+			requirement: Requirement{Roles: []string{"admin"}},
+			status:      http.StatusForbidden,
+		},
+		"policy denied": {
+			principal: john,
+			requirement: Requirement{
+				Scopes: []string{"read"},
+				Policy: func(r *http.Request, p *Principal) bool {
+					return p.Subject == "jane"
+				},
+			},
+			status: http.StatusForbidden,
+		},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+		w := serveWithPrincipal(item.principal, Authorize(config, item.requirement)(ok), req)
+
+		assert.Equal(t, item.status, w.Code, name)
+
+		if item.challenge != "" {
+			assert.Equal(t, item.challenge, w.Header().Get("WWW-Authenticate"), name)
+		}
+	}
+}
+
+func TestRequireHelpersPerRoute(t *testing.T) {
+	config := &Configuration{
+		Realm: "Test",
+	}
+
+	provider := ProviderFunc(func(r *http.Request) (*Principal, error) {
+		return &Principal{
+			Subject: "john",
+			Scopes:  []string{"articles:read"},
+			Roles:   []string{"editor"},
+		}, nil
+	})
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := mux.NewRouter()
+	r.Use(Handler(config, provider))
+	r.Handle("/articles", RequireScopes(config, "articles:read")(ok)).Methods(http.MethodGet)
+	r.Handle("/articles", RequireScopes(config, "articles:write")(ok)).Methods(http.MethodPost)
+	r.Handle("/admin", RequireRoles(config, "admin")(ok)).Methods(http.MethodGet)
+	r.Handle("/articles/{id}", RequirePolicy(config, func(r *http.Request, p *Principal) bool {
+		return mux.Vars(r)["id"] == p.Subject
+	})(ok)).Methods(http.MethodGet)
+
+	for _, item := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/articles", http.StatusOK},
+		{http.MethodPost, "/articles", http.StatusForbidden},
+		{http.MethodGet, "/admin", http.StatusForbidden},
+		{http.MethodGet, "/articles/john", http.StatusOK},
+		{http.MethodGet, "/articles/jane", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(item.method, "http://example.com"+item.path, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, item.status, w.Code, item.method+" "+item.path)
+	}
+}
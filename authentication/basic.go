@@ -0,0 +1,128 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// SchemeBasic is the HTTP Basic authentication scheme (RFC 7617).
+const SchemeBasic = "Basic"
+
+// dummyHash is compared when the user does not exist, to not leak the users by timing.
+const dummyHash = "$2a$10$547MDckuN/JhHij2pOaXduU7UMcw2VsdJ2LnCrcRlndK1XJhdW0Wa"
+
+// CredentialStore interface.
+type CredentialStore interface {
+	// Verify returns the Principal of the user when the password matches.
+	Verify(username string, password string) (*Principal, error)
+}
+
+// Basic provider authenticates the HTTP Basic credentials with the store.
+func Basic(store CredentialStore) Provider {
+	return Named("basic", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			return nil, &Error{
+				Scheme: SchemeBasic,
+				Err:    ErrNoCredentials,
+			}
+		}
+
+		principal, err := store.Verify(username, password)
+		if err != nil {
+			return nil, &Error{
+				Scheme: SchemeBasic,
+				Err:    err,
+			}
+		}
+
+		return principal, nil
+	}))
+}
+
+// Htpasswd is a CredentialStore backed by an htpasswd file of "username:hash" lines,
+// with bcrypt or argon2id hashes. The file is reloaded when it changes.
+type Htpasswd struct {
+	file  *reloadableFile
+	mtx   sync.RWMutex
+	users map[string]string
+}
+
+// NewHtpasswd constructor.
+func NewHtpasswd(filename string) (*Htpasswd, error) {
+	h := &Htpasswd{
+		users: make(map[string]string),
+	}
+
+	h.file = &reloadableFile{
+		filename: filename,
+		interval: DefaultReloadInterval,
+		parse:    h.parse,
+	}
+
+	if err := h.file.load(); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *Htpasswd) parse(r io.Reader) error {
+	users := make(map[string]string)
+
+	if err := scanLines(r, func(line string, n int) error {
+		username, hash, ok := strings.Cut(line, ":")
+		if !ok || username == "" {
+			return fmt.Errorf("invalid htpasswd line %d", n)
+		}
+
+		if err := validateHash(hash); err != nil {
+			return fmt.Errorf("invalid htpasswd hash line %d: %w", n, err)
+		}
+
+		users[username] = hash
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	h.mtx.Lock()
+	h.users = users
+	h.mtx.Unlock()
+
+	return nil
+}
+
+// Verify implements CredentialStore.
+func (h *Htpasswd) Verify(username string, password string) (*Principal, error) {
+	h.file.reloadIfChanged()
+
+	h.mtx.RLock()
+	hash, exists := h.users[username]
+	h.mtx.RUnlock()
+
+	if !exists {
+		hash = dummyHash
+	}
+
+	ok, err := VerifyHash(hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	if !ok || !exists {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{
+		Subject: username,
+	}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeFile(t *testing.T, filename string, content string, modTime time.Time) {
+	t.Helper()
+
+	assert.NoError(t, os.WriteFile(filename, []byte(content), 0o600))
+	assert.NoError(t, os.Chtimes(filename, modTime, modTime))
+}
+
+func TestHtpasswd(t *testing.T) {
+	johnHash, err := bcrypt.GenerateFromPassword([]byte("john-pass"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	janeHash, err := HashArgon2id("jane-pass")
+	assert.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), ".htpasswd")
+
+	writeFile(t, filename, "# users\njohn:"+string(johnHash)+"\n\njane:"+janeHash+"\n", time.Now().Add(-time.Hour))
+
+	store, err := NewHtpasswd(filename)
+	assert.NoError(t, err)
+
+	principal, err := store.Verify("john", "john-pass")
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+
+	principal, err = store.Verify("jane", "jane-pass")
+	assert.NoError(t, err)
+	assert.Equal(t, "jane", principal.Subject)
+
+	_, err = store.Verify("john", "bad")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = store.Verify("unknown", "dummy")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestHtpasswdReload(t *testing.T) {
+	hash, err := HashSHA256("pass")
+	assert.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), ".htpasswd")
+
+	writeFile(t, filename, "john:"+hash+"\n", time.Now().Add(-time.Hour))
+
+	store, err := NewHtpasswd(filename)
+	assert.NoError(t, err)
+
+	store.file.interval = 0
+
+	_, err = store.Verify("jane", "pass")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	writeFile(t, filename, "john:"+hash+"\njane:"+hash+"\n", time.Now())
+
+	_, err = store.Verify("jane", "pass")
+	assert.NoError(t, err)
+
+	// A broken file keeps the previous users.
+	writeFile(t, filename, "broken\n", time.Now().Add(time.Minute))
+
+	_, err = store.Verify("jane", "pass")
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.Remove(filename))
+
+	_, err = store.Verify("jane", "pass")
+	assert.NoError(t, err)
+}
+
+func TestNewHtpasswdWithBadFile(t *testing.T) {
+	_, err := NewHtpasswd(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	filename := filepath.Join(t.TempDir(), ".htpasswd")
+
+	writeFile(t, filename, "john\n", time.Now())
+
+	_, err = NewHtpasswd(filename)
+	assert.Error(t, err)
+
+	writeFile(t, filename, "john:$argon2id$v=19$m=65536,t=3,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5\n", time.Now())
+
+	_, err = NewHtpasswd(filename)
+	assert.ErrorIs(t, err, ErrUnsupportedHash)
+}
+
+func TestBasic(t *testing.T) {
+	hash, err := HashSHA256("pass")
+	assert.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), ".htpasswd")
+
+	writeFile(t, filename, "john:"+hash+"\n", time.Now())
+
+	store, err := NewHtpasswd(filename)
+	assert.NoError(t, err)
+
+	provider := Basic(store)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	_, err = provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	req.SetBasicAuth("john", "bad")
+
+	_, err = provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Equal(t, `Basic realm="Test", charset="UTF-8"`, challengeFromError("Test", err))
+
+	req.SetBasicAuth("john", "pass")
+
+	principal, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+}
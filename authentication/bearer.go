@@ -0,0 +1,42 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// SchemeBearer is the OAuth 2.0 Bearer token authentication scheme (RFC 6750).
+const SchemeBearer = "Bearer"
+
+// TokenValidatorFunc validates a bearer token and returns the Principal.
+type TokenValidatorFunc func(ctx context.Context, token string) (*Principal, error)
+
+// BearerToken returns the token of the RFC 6750 Authorization request header.
+func BearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[7:])
+
+	return token, token != ""
+}
+
+// Bearer provider extracts the bearer token from the request and validates it with validator.
+func Bearer(validator TokenValidatorFunc) Provider {
+	return Named("bearer", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		token, ok := BearerToken(r)
+		if !ok {
+			return nil, ErrNoCredentials
+		}
+
+		return validator(r.Context(), token)
+	}))
+}
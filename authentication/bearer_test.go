@@ -0,0 +1,58 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	_, ok := BearerToken(req)
+	assert.False(t, ok)
+
+	req.Header.Set("Authorization", "Basic Zm9vOmJhcg==")
+
+	_, ok = BearerToken(req)
+	assert.False(t, ok)
+
+	req.Header.Set("Authorization", "bearer  my-token ")
+
+	token, ok := BearerToken(req)
+	assert.True(t, ok)
+	assert.Equal(t, "my-token", token)
+}
+
+func TestBearer(t *testing.T) {
+	provider := Bearer(func(ctx context.Context, token string) (*Principal, error) {
+		if token != "secret" {
+			return nil, ErrInvalidCredentials
+		}
+
+		return &Principal{Subject: "john"}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	_, err := provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	req.Header.Set("Authorization", "Bearer bad")
+
+	_, err = provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	req.Header.Set("Authorization", "Bearer secret")
+
+	principal, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"crypto/x509"
+	"net/http"
+)
+
+// CertificateValidatorFunc validates a verified client certificate and returns the Principal.
+type CertificateValidatorFunc func(cert *x509.Certificate) (*Principal, error)
+
+// ClientCertificate provider authenticates the caller with the client certificate
+// verified during the mutual TLS handshake (tls.Config.ClientAuth must verify certificates).
+// When validator is nil, the subject common name is used as principal subject.
+func ClientCertificate(validator CertificateValidatorFunc) Provider {
+	if validator == nil {
+		validator = func(cert *x509.Certificate) (*Principal, error) {
+			return &Principal{
+				Subject: cert.Subject.CommonName,
+			}, nil
+		}
+	}
+
+	return Named("certificate", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			return nil, ErrNoCredentials
+		}
+
+		return validator(r.TLS.VerifiedChains[0][0])
+	}))
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCertificate(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/foo", nil)
+	req.TLS = &tls.ConnectionState{}
+
+	provider := ClientCertificate(nil)
+
+	_, err := provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	req.TLS.VerifiedChains = [][]*x509.Certificate{
+		{
+			{
+				Subject: pkix.Name{
+					CommonName: "billing-service",
+				},
+			},
+		},
+	}
+
+	principal, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "billing-service", principal.Subject)
+
+	provider = ClientCertificate(func(cert *x509.Certificate) (*Principal, error) {
+		return nil, ErrInvalidCredentials
+	})
+
+	_, err = provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
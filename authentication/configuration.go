@@ -4,7 +4,91 @@
 
 package authentication
 
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+// RequestMatcherFunc is the type of a function for use in Configuration.Public.
+type RequestMatcherFunc func(r *http.Request) bool
+
+// DefaultPublic matches the health check, probes and metrics endpoints, the detailed
+// health check (/health/details) requires authentication.
+var DefaultPublic = PublicPaths("/health", "/health/live", "/health/ready", "/health/startup", "/metrics")
+
 // Configuration struct.
 type Configuration struct {
 	Realm string
+	// Public matches the requests skipping the authentication, DefaultPublic is used when nil.
+	Public RequestMatcherFunc
+	// AuditSampler rate limits the failure audit logs, a zerolog.BurstSampler
+	// of DefaultAuditBurst events per DefaultAuditPeriod is used when nil.
+	AuditSampler zerolog.Sampler
+	// Metrics options of the http_authentication_total counter, only the Namespace, the Subsystem,
+	// the ConstLabels and the Registerer are used.
+	Metrics metrics.Options
+
+	auditOnce   sync.Once
+	audit       *zerolog.Logger
+	metricsOnce sync.Once
+	total       *prometheus.CounterVec
+}
+
+// PublicPaths returns a RequestMatcherFunc matching the exact paths.
+func PublicPaths(paths ...string) RequestMatcherFunc {
+	set := make(map[string]struct{}, len(paths))
+
+	for _, path := range paths {
+		set[path] = struct{}{}
+	}
+
+	return func(r *http.Request) bool {
+		_, ok := set[r.URL.Path]
+
+		return ok
+	}
+}
+
+// PublicPrefixes returns a RequestMatcherFunc matching the paths starting with one of the prefixes.
+func PublicPrefixes(prefixes ...string) RequestMatcherFunc {
+	return func(r *http.Request) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// AnyOf returns a RequestMatcherFunc matching the requests matched by one of the matchers.
+func AnyOf(matchers ...RequestMatcherFunc) RequestMatcherFunc {
+	return func(r *http.Request) bool {
+		for _, matcher := range matchers {
+			if matcher(r) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// NoPublic is a RequestMatcherFunc which matches no requests.
+func NoPublic(*http.Request) bool {
+	return false
+}
+
+func (c *Configuration) isPublic(r *http.Request) bool {
+	if c.Public == nil {
+		return DefaultPublic(r)
+	}
+
+	return c.Public(r)
 }
@@ -0,0 +1,41 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicMatchers(t *testing.T) {
+	req := func(path string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+	}
+
+	assert.True(t, DefaultPublic(req("/health")))
+	assert.True(t, DefaultPublic(req("/health/live")))
+	assert.True(t, DefaultPublic(req("/health/ready")))
+	assert.True(t, DefaultPublic(req("/health/startup")))
+	assert.True(t, DefaultPublic(req("/metrics")))
+	assert.False(t, DefaultPublic(req("/health/details")))
+
+	matcher := AnyOf(PublicPaths("/login"), PublicPrefixes("/assets/"))
+
+	assert.True(t, matcher(req("/login")))
+	assert.True(t, matcher(req("/assets/app.js")))
+	assert.False(t, matcher(req("/admin")))
+
+	assert.False(t, NoPublic(req("/health")))
+}
+
+func TestConfigurationIsPublic(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/health", nil)
+
+	assert.True(t, (&Configuration{}).isPublic(req))
+	assert.False(t, (&Configuration{Public: NoPublic}).isPublic(req))
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RFC 6750 error codes.
+const (
+	ErrorCodeInvalidRequest    = "invalid_request"
+	ErrorCodeInvalidToken      = "invalid_token"
+	ErrorCodeInsufficientScope = "insufficient_scope"
+)
+
+// Error is an authentication error carrying the challenge scheme, the RFC 6750 error code
+// and description returned to the client in the WWW-Authenticate header.
+// The Bearer scheme is used when Scheme is empty.
+type Error struct {
+	Scheme      string
+	Code        string
+	Description string
+	Err         error
+}
+
+// NewInvalidTokenError returns an Error with the invalid_token code.
+func NewInvalidTokenError(err error, description string) *Error {
+	return &Error{
+		Code:        ErrorCodeInvalidToken,
+		Description: description,
+		Err:         err,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+
+	if e.Err == nil {
+		return fmt.Sprintf("%s: %s", e.Code, e.Description)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", e.Code, e.Description, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// challengeFromError returns the WWW-Authenticate header value for the authentication error.
+func challengeFromError(realm string, err error) string {
+	var authErr *Error
+	if !errors.As(err, &authErr) {
+		return challenge(SchemeBearer, "realm", realm)
+	}
+
+	switch authErr.Scheme {
+	case "", SchemeBearer:
+		return challenge(
+			SchemeBearer,
+			"realm", realm,
+			"error", authErr.Code,
+			"error_description", authErr.Description,
+		)
+	case SchemeBasic:
+		return challenge(SchemeBasic, "realm", realm, "charset", "UTF-8")
+	default:
+		return challenge(authErr.Scheme, "realm", realm)
+	}
+}
+
+// challenge returns the WWW-Authenticate header value for the scheme,
+// params are key/value pairs and empty values are omitted.
+func challenge(scheme string, params ...string) string {
+	var b strings.Builder
+
+	b.WriteString(scheme)
+
+	sep := " "
+
+	for i := 0; i+1 < len(params); i += 2 {
+		if params[i+1] == "" {
+			continue
+		}
+
+		b.WriteString(sep)
+		b.WriteString(params[i])
+		b.WriteString(`="`)
+		b.WriteString(quoteEscaper.Replace(params[i+1]))
+		b.WriteByte('"')
+
+		sep = ", "
+	}
+
+	return b.String()
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
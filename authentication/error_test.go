@@ -0,0 +1,43 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	err := NewInvalidTokenError(ErrExpiredCredentials, "The access token expired")
+
+	assert.Equal(t, "invalid_token: The access token expired: expired credentials", err.Error())
+	assert.ErrorIs(t, err, ErrExpiredCredentials)
+
+	err = &Error{
+		Code:        ErrorCodeInvalidRequest,
+		Description: "Bad request",
+	}
+
+	assert.Equal(t, "invalid_request: Bad request", err.Error())
+
+	err = &Error{
+		Scheme: SchemeBasic,
+		Err:    ErrInvalidCredentials,
+	}
+
+	assert.Equal(t, "invalid credentials", err.Error())
+}
+
+func TestChallenge(t *testing.T) {
+	assert.Equal(t, "Bearer", challenge("Bearer"))
+	assert.Equal(t, `Bearer realm="Test"`, challenge("Bearer", "realm", "Test"))
+	assert.Equal(t, `Bearer error="invalid_token"`, challenge("Bearer", "realm", "", "error", "invalid_token"))
+	assert.Equal(
+		t,
+		`Bearer realm="Test", error="invalid_token", error_description="The \"token\" expired"`,
+		challenge("Bearer", "realm", "Test", "error", "invalid_token", "error_description", `The "token" expired`),
+	)
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultReloadInterval sets the minimum interval between two checks of a credential file (5s).
+var DefaultReloadInterval = 5 * time.Second
+
+// reloadableFile parses a credential file and reloads it when its modification time
+// or size change, the file is checked at most once per interval.
+type reloadableFile struct {
+	filename  string
+	interval  time.Duration
+	parse     func(r io.Reader) error
+	mtx       sync.Mutex
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+func (f *reloadableFile) load() error {
+	file, err := os.Open(f.filename)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Error().Err(err).Msgf("Close %s file", f.filename)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	if err := f.parse(file); err != nil {
+		return err
+	}
+
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	return nil
+}
+
+// reloadIfChanged keeps the previous credentials when the new file cannot be loaded.
+func (f *reloadableFile) reloadIfChanged() {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if time.Since(f.checkedAt) < f.interval {
+		return
+	}
+
+	f.checkedAt = time.Now()
+
+	info, err := os.Stat(f.filename)
+	if err != nil {
+		log.Error().Err(err).Str("file", f.filename).Msg("Credential file stat failed")
+
+		return
+	}
+
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return
+	}
+
+	if err := f.load(); err != nil {
+		log.Error().Err(err).Str("file", f.filename).Msg("Credential file reload failed")
+
+		return
+	}
+
+	log.Info().Str("file", f.filename).Msg("Credential file reloaded")
+}
+
+// scanLines calls fn with the non empty and non comment lines of r.
+func scanLines(r io.Reader, fn func(line string, n int) error) error {
+	scanner := bufio.NewScanner(r)
+
+	n := 0
+
+	for scanner.Scan() {
+		n++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := fn(line, n); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return nil
+}
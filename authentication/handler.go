@@ -6,35 +6,56 @@ package authentication
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 
+	"github.com/euskadi31/go-server/internal/reqinfo"
 	"github.com/euskadi31/go-server/response"
-	"github.com/rs/zerolog/log"
 )
 
-// Handler authentication.
+// Handler authentication, the outcomes are counted in the http_authentication_total
+// metric and logged as audit events.
 func Handler(config *Configuration, provider Provider) func(http.Handler) http.Handler {
+	config.authenticationTotal()
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip public endpoints
-			if r.URL.Path == "/health" || r.URL.Path == "/metrics" {
+			if config.isPublic(r) {
 				next.ServeHTTP(w, r)
 
 				return
 			}
 
-			if !provider.Validate(r) {
-				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s"`, config.Realm))
+			principal, err := provider.Authenticate(r)
+			if err == nil && principal == nil {
+				// A provider returning neither a principal nor an error did not authenticate the request.
+				err = ErrNoCredentials
+			}
+
+			if errors.Is(err, ErrUnavailable) {
+				// The credentials were not rejected, the client must not discard them.
+				config.auditEvent(r, OutcomeFailure, providerFromError(err), ReasonUnavailable, nil, err)
+
+				response.FailureFromError(w, http.StatusServiceUnavailable, errors.New("Service Unavailable"))
+
+				return
+			}
+
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", challengeFromError(config.Realm, err))
 
-				log.Error().Msg("Access token invalid or expired")
+				config.auditEvent(r, OutcomeFailure, providerFromError(err), reasonFromError(err), nil, err)
 
 				response.FailureFromError(w, http.StatusUnauthorized, errors.New("Unauthorized"))
 
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			config.auditEvent(r, OutcomeSuccess, principal.Provider, "", principal, nil)
+
+			reqinfo.SetPrincipal(r.Context(), principal.Subject)
+
+			next.ServeHTTP(w, r.WithContext(ToContext(r.Context(), principal)))
 		})
 	}
 }
@@ -5,6 +5,7 @@
 package authentication
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -17,7 +18,7 @@ import (
 func TestHandler(t *testing.T) {
 	provider := &MockProvider{}
 
-	provider.On("Validate", mock.Anything).Return(true)
+	provider.On("Authenticate", mock.Anything).Return(&Principal{Subject: "john"}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
 	w := httptest.NewRecorder()
@@ -25,6 +26,8 @@ func TestHandler(t *testing.T) {
 	middleware := alice.New(Handler(&Configuration{
 		Realm: "Test",
 	}, provider)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "john", SubjectFromContext(r.Context()))
+
 		w.WriteHeader(http.StatusOK)
 	})
 
@@ -36,7 +39,7 @@ func TestHandler(t *testing.T) {
 func TestHandlerWithBadAuth(t *testing.T) {
 	provider := &MockProvider{}
 
-	provider.On("Validate", mock.Anything).Return(false)
+	provider.On("Authenticate", mock.Anything).Return(nil, ErrInvalidCredentials)
 
 	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
 	w := httptest.NewRecorder()
@@ -49,13 +52,35 @@ func TestHandlerWithBadAuth(t *testing.T) {
 
 	middleware.ServeHTTP(w, req)
 
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="Test"`, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestHandlerWithoutPrincipal(t *testing.T) {
+	provider := &MockProvider{}
+
+	provider.On("Authenticate", mock.Anything).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	middleware := alice.New(Handler(&Configuration{
+		Realm: "Test",
+	}, provider)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	assert.NotPanics(t, func() {
+		middleware.ServeHTTP(w, req)
+	})
+
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestHandlerOnHealthEndpoint(t *testing.T) {
 	provider := &MockProvider{}
 
-	provider.On("Validate", mock.Anything).Return(false)
+	provider.On("Authenticate", mock.Anything).Return(nil, ErrInvalidCredentials)
 
 	req := httptest.NewRequest(http.MethodGet, "http://example.com/health", nil)
 	w := httptest.NewRecorder()
@@ -70,3 +95,120 @@ func TestHandlerOnHealthEndpoint(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestHandlerWithInvalidToken(t *testing.T) {
+	provider := &MockProvider{}
+
+	provider.On("Authenticate", mock.Anything).Return(nil, NewInvalidTokenError(ErrExpiredCredentials, "The access token expired"))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	middleware := alice.New(Handler(&Configuration{
+		Realm: "Test",
+	}, provider)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	middleware.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="Test", error="invalid_token", error_description="The access token expired"`, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestHandlerWithPublicMatcher(t *testing.T) {
+	provider := &MockProvider{}
+
+	provider.On("Authenticate", mock.Anything).Return(nil, ErrNoCredentials)
+
+	middleware := alice.New(Handler(&Configuration{
+		Realm:  "Test",
+		Public: PublicPrefixes("/public/"),
+	}, provider)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for path, status := range map[string]int{
+		"/public/index.html": http.StatusOK,
+		"/health":            http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		w := httptest.NewRecorder()
+
+		middleware.ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, path)
+	}
+}
+
+func TestHandlerChallengeUsesFailingProviderScheme(t *testing.T) {
+	hash, err := HashSHA256("pass")
+	assert.NoError(t, err)
+
+	provider := Chain(
+		Bearer(func(ctx context.Context, token string) (*Principal, error) {
+			return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token is malformed")
+		}),
+		Basic(htpasswdFunc(func(username string, password string) (*Principal, error) {
+			if ok, _ := VerifyHash(hash, password); ok {
+				return &Principal{Subject: username}, nil
+			}
+
+			return nil, ErrInvalidCredentials
+		})),
+	)
+
+	middleware := alice.New(Handler(&Configuration{
+		Realm: "Test",
+	}, provider)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for name, item := range map[string]struct {
+		setup     func(r *http.Request)
+		status    int
+		challenge string
+	}{
+		"no credentials": {
+			setup:     func(r *http.Request) {},
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer realm="Test"`,
+		},
+		"bad token": {
+			setup: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer foo")
+			},
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer realm="Test", error="invalid_token", error_description="The access token is malformed"`,
+		},
+		"bad password": {
+			setup: func(r *http.Request) {
+				r.SetBasicAuth("john", "bad")
+			},
+			status:    http.StatusUnauthorized,
+			challenge: `Basic realm="Test", charset="UTF-8"`,
+		},
+		"good password": {
+			setup: func(r *http.Request) {
+				r.SetBasicAuth("john", "pass")
+			},
+			status: http.StatusOK,
+		},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+		item.setup(req)
+
+		w := httptest.NewRecorder()
+
+		middleware.ServeHTTP(w, req)
+
+		assert.Equal(t, item.status, w.Code, name)
+		assert.Equal(t, item.challenge, w.Header().Get("WWW-Authenticate"), name)
+	}
+}
+
+type htpasswdFunc func(username string, password string) (*Principal, error)
+
+func (f htpasswdFunc) Verify(username string, password string) (*Principal, error) {
+	return f(username, password)
+}
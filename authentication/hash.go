@@ -0,0 +1,192 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Argon2id parameters used by HashArgon2id (RFC 9106 second recommended option).
+const (
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	saltLen       = 16
+)
+
+// Upper bounds of the argon2id parameters accepted by VerifyHash, a hash with larger
+// parameters would let a credentials file make each verification arbitrarily expensive.
+const (
+	argon2MaxMemory  = 1024 * 1024 // KiB
+	argon2MaxTime    = 16
+	argon2MaxThreads = 16
+)
+
+// ErrUnsupportedHash is returned when the hash format is not supported.
+var ErrUnsupportedHash = errors.New("unsupported hash format")
+
+// HashArgon2id returns the PHC string of the argon2id hash of secret.
+func HashArgon2id(secret string) (string, error) {
+	salt := make([]byte, saltLen)
+
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(secret), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		argon2Memory,
+		argon2Time,
+		argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// HashSHA256 returns the salted SHA-256 hash of secret ("$sha256$salt$hash"),
+// it must only be used for high entropy secrets like generated API keys.
+func HashSHA256(secret string) (string, error) {
+	salt := make([]byte, saltLen)
+
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return encodeSHA256(salt, secret), nil
+}
+
+func encodeSHA256(salt []byte, secret string) string {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), secret...))
+
+	return "$sha256$" + base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// VerifyHash compares in constant time the secret with the encoded hash,
+// bcrypt ($2a$, $2b$, $2y$), argon2id and salted SHA-256 hashes are supported.
+func VerifyHash(encoded string, secret string) (bool, error) {
+	switch {
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(secret))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("invalid bcrypt hash: %w", err)
+		}
+
+		return true, nil
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return verifyArgon2id(encoded, secret)
+	case strings.HasPrefix(encoded, "$sha256$"):
+		parts := strings.Split(encoded, "$")
+		if len(parts) != 4 {
+			return false, ErrUnsupportedHash
+		}
+
+		salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+		if err != nil {
+			return false, fmt.Errorf("invalid sha256 salt: %w", err)
+		}
+
+		return subtle.ConstantTimeCompare([]byte(encodeSHA256(salt, secret)), []byte(encoded)) == 1, nil
+	default:
+		return false, ErrUnsupportedHash
+	}
+}
+
+// validateHash checks the parameters of the encoded hash, the credentials files validate
+// their hashes when they are loaded.
+func validateHash(encoded string) error {
+	if strings.HasPrefix(encoded, "$argon2id$") {
+		_, err := parseArgon2id(encoded)
+
+		return err
+	}
+
+	return nil
+}
+
+type argon2idHash struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func parseArgon2id(encoded string) (*argon2idHash, error) {
+	// $argon2id$v=19$m=65536,t=3,p=4$salt$key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return nil, ErrUnsupportedHash
+	}
+
+	var version int
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrUnsupportedHash
+	}
+
+	var memory, time, threads uint32
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return nil, ErrUnsupportedHash
+	}
+
+	if threads < 1 || threads > argon2MaxThreads {
+		return nil, fmt.Errorf("%w: argon2id parallelism must be between 1 and %d", ErrUnsupportedHash, argon2MaxThreads)
+	}
+
+	if time < 1 || time > argon2MaxTime {
+		return nil, fmt.Errorf("%w: argon2id time must be between 1 and %d", ErrUnsupportedHash, argon2MaxTime)
+	}
+
+	if memory < 8*threads || memory > argon2MaxMemory {
+		return nil, fmt.Errorf("%w: argon2id memory must be between 8*p and %d KiB", ErrUnsupportedHash, argon2MaxMemory)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, fmt.Errorf("invalid argon2id key: %w", err)
+	}
+
+	return &argon2idHash{
+		memory:  memory,
+		time:    time,
+		threads: uint8(threads),
+		salt:    salt,
+		key:     key,
+	}, nil
+}
+
+func verifyArgon2id(encoded string, secret string) (bool, error) {
+	h, err := parseArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	actual := argon2.IDKey([]byte(secret), h.salt, h.time, h.memory, h.threads, uint32(len(h.key))) // nolint: gosec
+
+	return subtle.ConstantTimeCompare(actual, h.key) == 1, nil
+}
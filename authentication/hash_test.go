@@ -0,0 +1,69 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestVerifyHash(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	argon2Hash, err := HashArgon2id("secret")
+	assert.NoError(t, err)
+
+	sha256Hash, err := HashSHA256("secret")
+	assert.NoError(t, err)
+
+	for _, hash := range []string{string(bcryptHash), argon2Hash, sha256Hash} {
+		ok, err := VerifyHash(hash, "secret")
+		assert.NoError(t, err)
+		assert.True(t, ok, hash)
+
+		ok, err = VerifyHash(hash, "bad")
+		assert.NoError(t, err)
+		assert.False(t, ok, hash)
+	}
+
+	for _, hash := range []string{
+		"{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=",
+		"$argon2id$v=19$bad",
+		"$argon2id$v=16$m=65536,t=3,p=4$c2FsdA$a2V5",
+		"$sha256$bad",
+	} {
+		_, err := VerifyHash(hash, "secret")
+		assert.ErrorIs(t, err, ErrUnsupportedHash, hash)
+	}
+
+	_, err = VerifyHash("$2a$10$bad", "secret")
+	assert.Error(t, err)
+}
+
+func TestValidateHashWithInvalidArgon2idParameters(t *testing.T) {
+	for name, hash := range map[string]string{
+		"p=0":          "$argon2id$v=19$m=65536,t=3,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5",
+		"p too large":  "$argon2id$v=19$m=65536,t=3,p=255$c2FsdHNhbHQ$a2V5a2V5a2V5",
+		"t=0":          "$argon2id$v=19$m=65536,t=0,p=4$c2FsdHNhbHQ$a2V5a2V5a2V5",
+		"t too large":  "$argon2id$v=19$m=65536,t=1000,p=4$c2FsdHNhbHQ$a2V5a2V5a2V5",
+		"m too large":  "$argon2id$v=19$m=4294967295,t=3,p=4$c2FsdHNhbHQ$a2V5a2V5a2V5",
+		"m below 8*p":  "$argon2id$v=19$m=16,t=3,p=4$c2FsdHNhbHQ$a2V5a2V5a2V5",
+		"p overflow":   "$argon2id$v=19$m=65536,t=3,p=4294967296$c2FsdHNhbHQ$a2V5a2V5a2V5",
+		"missing part": "$argon2id$v=19$m=65536,t=3$c2FsdHNhbHQ$a2V5a2V5a2V5",
+	} {
+		err := validateHash(hash)
+		assert.ErrorIs(t, err, ErrUnsupportedHash, name)
+
+		_, err = VerifyHash(hash, "secret")
+		assert.ErrorIs(t, err, ErrUnsupportedHash, name)
+	}
+
+	hash, err := HashArgon2id("secret")
+	assert.NoError(t, err)
+	assert.NoError(t, validateHash(hash))
+}
@@ -0,0 +1,334 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemeHMAC is the authentication scheme of the HMAC signed requests.
+const SchemeHMAC = "HMAC-SHA256"
+
+var (
+	// DefaultHMACWindow sets the maximum clock difference accepted for the signature timestamp (5m).
+	DefaultHMACWindow = 5 * time.Minute
+
+	// DefaultHMACMaxBodySize sets the maximum size of the body read to compute its digest (10MB).
+	DefaultHMACMaxBodySize int64 = 10 << 20
+
+	// DefaultHMACSignedHeaders are the headers which must always be signed.
+	DefaultHMACSignedHeaders = []string{"host"}
+)
+
+// HMACKeyStore interface.
+type HMACKeyStore interface {
+	// Secret returns the shared secret of the key ID.
+	Secret(keyID string) ([]byte, error)
+}
+
+// StaticHMACKeys is a HMACKeyStore of secrets indexed by key ID.
+type StaticHMACKeys map[string][]byte
+
+// Secret implements HMACKeyStore.
+func (k StaticHMACKeys) Secret(keyID string) ([]byte, error) {
+	secret, ok := k[keyID]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return secret, nil
+}
+
+// HMACConfiguration struct.
+type HMACConfiguration struct {
+	Keys HMACKeyStore
+	// Nonces used for replay protection, a MemoryNonceStore is used when nil.
+	Nonces NonceStore
+	// Window of accepted timestamps around the server time, DefaultHMACWindow is used when zero.
+	Window time.Duration
+	// SignedHeaders must be part of the signature, DefaultHMACSignedHeaders is used when nil.
+	SignedHeaders []string
+	MaxBodySize   int64
+}
+
+// HMAC provider verifies requests signed with SignHMAC, the signature covers the method,
+// the path, the query, the signed headers, the timestamp, the nonce and the body digest:
+//
+//	Authorization: HMAC-SHA256 keyId="k1", headers="host content-type", timestamp="1700000000",
+//	    nonce="5f1c...", signature="base64(hmac-sha256(secret, canonical request))"
+//
+// The key ID is the subject of the Principal.
+func HMAC(cfg *HMACConfiguration) Provider {
+	if cfg.Nonces == nil {
+		cfg.Nonces = NewMemoryNonceStore()
+	}
+
+	if cfg.Window == 0 {
+		cfg.Window = DefaultHMACWindow
+	}
+
+	if cfg.SignedHeaders == nil {
+		cfg.SignedHeaders = DefaultHMACSignedHeaders
+	}
+
+	if cfg.MaxBodySize == 0 {
+		cfg.MaxBodySize = DefaultHMACMaxBodySize
+	}
+
+	return Named("hmac", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		principal, err := verifyHMAC(cfg, r, time.Now())
+		if err != nil {
+			return nil, &Error{
+				Scheme: SchemeHMAC,
+				Err:    err,
+			}
+		}
+
+		return principal, nil
+	}))
+}
+
+func verifyHMAC(cfg *HMACConfiguration, r *http.Request, now time.Time) (*Principal, error) {
+	header := r.Header.Get("Authorization")
+
+	scheme, rest, _ := strings.Cut(header, " ")
+	if !strings.EqualFold(scheme, SchemeHMAC) {
+		return nil, ErrNoCredentials
+	}
+
+	params := parseAuthParams(rest)
+
+	keyID := params["keyid"]
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+
+	if keyID == "" || params["nonce"] == "" || err != nil || len(signature) == 0 {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidCredentials)
+	}
+
+	timestamp, err := strconv.ParseInt(params["timestamp"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed timestamp", ErrInvalidCredentials)
+	}
+
+	signedAt := time.Unix(timestamp, 0)
+	if signedAt.Before(now.Add(-cfg.Window)) || signedAt.After(now.Add(cfg.Window)) {
+		return nil, fmt.Errorf("%w: signature timestamp out of window", ErrExpiredCredentials)
+	}
+
+	headers := strings.Fields(strings.ToLower(params["headers"]))
+
+	for _, required := range cfg.SignedHeaders {
+		if !containsString(headers, strings.ToLower(required)) {
+			return nil, fmt.Errorf("%w: header %s must be signed", ErrInvalidCredentials, required)
+		}
+	}
+
+	secret, err := cfg.Keys.Secret(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	digest, err := bodyDigest(r, cfg.MaxBodySize)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := hmacSignature(secret, canonicalRequest(r, headers, params["timestamp"], params["nonce"], digest))
+
+	if !hmac.Equal(expected, signature) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidCredentials)
+	}
+
+	// The nonce is recorded after the signature check, to not let attackers burn nonces.
+	if !cfg.Nonces.Use(keyID+":"+params["nonce"], signedAt.Add(cfg.Window)) {
+		return nil, fmt.Errorf("%w: nonce already used", ErrInvalidCredentials)
+	}
+
+	return &Principal{
+		Subject: keyID,
+	}, nil
+}
+
+// SignHMAC signs the request for the HMAC provider, the host header is always signed.
+func SignHMAC(r *http.Request, keyID string, secret []byte, headers ...string) error {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	nonce := hex.EncodeToString(b)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	signed := []string{"host"}
+
+	for _, h := range headers {
+		if h = strings.ToLower(h); !containsString(signed, h) {
+			signed = append(signed, h)
+		}
+	}
+
+	digest, err := bodyDigest(r, -1)
+	if err != nil {
+		return err
+	}
+
+	signature := hmacSignature(secret, canonicalRequest(r, signed, timestamp, nonce, digest))
+
+	r.Header.Set("Authorization", challenge(
+		SchemeHMAC,
+		"keyId", keyID,
+		"headers", strings.Join(signed, " "),
+		"timestamp", timestamp,
+		"nonce", nonce,
+		"signature", base64.StdEncoding.EncodeToString(signature),
+	))
+
+	return nil
+}
+
+// canonicalRequest returns the signed string:
+//
+//	METHOD\npath\nsorted query\nname:value\n...\ntimestamp\nnonce\nhex(sha256(body))
+func canonicalRequest(r *http.Request, headers []string, timestamp string, nonce string, digest string) string {
+	var b strings.Builder
+
+	b.WriteString(r.Method)
+	b.WriteByte('\n')
+	b.WriteString(r.URL.EscapedPath())
+	b.WriteByte('\n')
+	b.WriteString(r.URL.Query().Encode())
+	b.WriteByte('\n')
+
+	for _, name := range headers {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.TrimSpace(value))
+		b.WriteByte('\n')
+	}
+
+	b.WriteString(timestamp)
+	b.WriteByte('\n')
+	b.WriteString(nonce)
+	b.WriteByte('\n')
+	b.WriteString(digest)
+
+	return b.String()
+}
+
+func hmacSignature(secret []byte, canonical string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(canonical))
+
+	return mac.Sum(nil)
+}
+
+// bodyDigest returns the hex SHA-256 of the body and restores it for the next handlers,
+// a negative limit disables the size check.
+func bodyDigest(r *http.Request, limit int64) (string, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		sum := sha256.Sum256(nil)
+
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	reader := io.Reader(r.Body)
+	if limit >= 0 {
+		reader = io.LimitReader(r.Body, limit+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read body: %w", err)
+	}
+
+	if err := r.Body.Close(); err != nil {
+		return "", fmt.Errorf("failed to close body: %w", err)
+	}
+
+	if limit >= 0 && int64(len(body)) > limit {
+		return "", errors.New("body too large to be verified")
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.Sum256(body)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// parseAuthParams parses the comma separated auth-param list of an Authorization header,
+// the names are lower cased.
+func parseAuthParams(s string) map[string]string {
+	params := make(map[string]string)
+
+	for s = strings.TrimSpace(s); s != ""; {
+		name, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			break
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		rest = strings.TrimSpace(rest)
+
+		var value strings.Builder
+
+		if strings.HasPrefix(rest, `"`) {
+			i := 1
+
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+
+				value.WriteByte(rest[i])
+			}
+
+			rest = rest[min(i+1, len(rest)):]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+
+			value.WriteString(strings.TrimSpace(rest[:end]))
+
+			rest = rest[end:]
+		}
+
+		params[name] = value.String()
+
+		s = strings.TrimLeft(rest, ", ")
+	}
+
+	return params
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/justinas/alice"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSignedRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.com/webhooks/github?b=2&a=1", strings.NewReader(body))
+	assert.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+
+	assert.NoError(t, SignHMAC(req, "github", []byte("secret"), "Content-Type"))
+
+	return req
+}
+
+func TestHMAC(t *testing.T) {
+	provider := HMAC(&HMACConfiguration{
+		Keys: StaticHMACKeys{
+			"github": []byte("secret"),
+		},
+		SignedHeaders: []string{"host", "content-type"},
+	})
+
+	req := newSignedRequest(t, `{"action":"opened"}`)
+
+	principal, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "github", principal.Subject)
+
+	// The body is still readable by the next handlers.
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"action":"opened"}`, string(body))
+}
+
+func TestHMACRejectsReplay(t *testing.T) {
+	provider := HMAC(&HMACConfiguration{
+		Keys: StaticHMACKeys{
+			"github": []byte("secret"),
+		},
+	})
+
+	req := newSignedRequest(t, `{}`)
+	authorization := req.Header.Get("Authorization")
+
+	_, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+
+	replay, err := http.NewRequest(http.MethodPost, "http://example.com/webhooks/github?b=2&a=1", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+
+	replay.Header.Set("Content-Type", "application/json")
+	replay.Header.Set("Authorization", authorization)
+
+	_, err = provider.Authenticate(replay)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Contains(t, err.Error(), "nonce already used")
+}
+
+func TestHMACRejectsTampering(t *testing.T) {
+	cfg := &HMACConfiguration{
+		Keys: StaticHMACKeys{
+			"github": []byte("secret"),
+		},
+		MaxBodySize: 32,
+	}
+
+	provider := HMAC(cfg)
+
+	for name, tamper := range map[string]func(r *http.Request){
+		"body": func(r *http.Request) {
+			r.Body = io.NopCloser(strings.NewReader(`{"action":"closed"}`))
+		},
+		"body too large": func(r *http.Request) {
+			r.Body = io.NopCloser(strings.NewReader(strings.Repeat("a", 64)))
+		},
+		"path": func(r *http.Request) {
+			r.URL.Path = "/webhooks/gitlab"
+		},
+		"query": func(r *http.Request) {
+			r.URL.RawQuery = "a=2&b=2"
+		},
+		"method": func(r *http.Request) {
+			r.Method = http.MethodPut
+		},
+		"signed header": func(r *http.Request) {
+			r.Header.Set("Content-Type", "text/plain")
+		},
+		"host": func(r *http.Request) {
+			r.Host = "evil.example.com"
+		},
+		"unknown key": func(r *http.Request) {
+			r.Header.Set("Authorization", strings.Replace(r.Header.Get("Authorization"), `keyId="github"`, `keyId="gitlab"`, 1))
+		},
+		"malformed signature": func(r *http.Request) {
+			r.Header.Set("Authorization", `HMAC-SHA256 keyId="github", signature="!!"`)
+		},
+		"malformed timestamp": func(r *http.Request) {
+			r.Header.Set("Authorization", `HMAC-SHA256 keyId="github", nonce="n", timestamp="now", signature="Zm9v"`)
+		},
+		"unsigned required header": func(r *http.Request) {
+			r.Header.Set("Authorization", strings.Replace(r.Header.Get("Authorization"), `headers="host content-type"`, `headers="content-type"`, 1))
+		},
+	} {
+		req := newSignedRequest(t, `{"action":"opened"}`)
+
+		tamper(req)
+
+		_, err := provider.Authenticate(req)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestHMACRejectsOldTimestamp(t *testing.T) {
+	cfg := &HMACConfiguration{
+		Keys: StaticHMACKeys{
+			"github": []byte("secret"),
+		},
+		Window:        time.Minute,
+		Nonces:        NewMemoryNonceStore(),
+		SignedHeaders: DefaultHMACSignedHeaders,
+	}
+
+	req := newSignedRequest(t, `{}`)
+
+	_, err := verifyHMAC(cfg, req, time.Now().Add(2*time.Minute))
+	assert.ErrorIs(t, err, ErrExpiredCredentials)
+
+	_, err = verifyHMAC(cfg, req, time.Now().Add(-2*time.Minute))
+	assert.ErrorIs(t, err, ErrExpiredCredentials)
+}
+
+func TestHMACWithoutCredentials(t *testing.T) {
+	provider := HMAC(&HMACConfiguration{
+		Keys: StaticHMACKeys{},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("Authorization", "Bearer foo")
+
+	_, err := provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+	assert.Equal(t, `HMAC-SHA256 realm="Test"`, challengeFromError("Test", err))
+}
+
+func TestHMACWithHandler(t *testing.T) {
+	provider := HMAC(&HMACConfiguration{
+		Keys: StaticHMACKeys{
+			"github": []byte("secret"),
+		},
+	})
+
+	server := httptest.NewServer(alice.New(Handler(&Configuration{
+		Realm: "Test",
+	}, provider)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		w.Header().Set("X-Key-ID", SubjectFromContext(r.Context()))
+		w.Header().Set("X-Body-Size", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/hooks", strings.NewReader(`{"id":1}`))
+	assert.NoError(t, err)
+
+	assert.NoError(t, SignHMAC(req, "github", []byte("secret")))
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "github", resp.Header.Get("X-Key-ID"))
+	assert.Equal(t, "8", resp.Header.Get("X-Body-Size"))
+}
+
+func TestParseAuthParams(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"keyid":     "k1",
+		"headers":   "host date",
+		"timestamp": "1700000000",
+		"quoted":    `a "b"`,
+	}, parseAuthParams(`keyId="k1", headers="host date",timestamp=1700000000, quoted="a \"b\""`))
+
+	assert.Empty(t, parseAuthParams(""))
+	assert.Empty(t, parseAuthParams("foo"))
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	store := NewMemoryNonceStore()
+
+	now := time.Now()
+	store.now = func() time.Time {
+		return now
+	}
+
+	assert.True(t, store.Use("a", now.Add(time.Minute)))
+	assert.False(t, store.Use("a", now.Add(time.Minute)))
+	assert.True(t, store.Use("b", now.Add(time.Minute)))
+
+	now = now.Add(2 * time.Minute)
+
+	assert.True(t, store.Use("c", now.Add(time.Minute)))
+	assert.Len(t, store.nonces, 1)
+	assert.True(t, store.Use("a", now.Add(time.Minute)))
+}
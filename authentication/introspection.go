@@ -0,0 +1,254 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// DefaultIntrospectionCacheTTL sets the maximum time an active token is cached (5m).
+	DefaultIntrospectionCacheTTL = 5 * time.Minute
+
+	// DefaultIntrospectionNegativeCacheTTL sets the time an inactive token is cached (30s).
+	DefaultIntrospectionNegativeCacheTTL = 30 * time.Second
+
+	// DefaultIntrospectionCacheSize sets the number of cached tokens before purging expired entries.
+	DefaultIntrospectionCacheSize = 10000
+
+	// DefaultIntrospectionTimeout sets the maximum time of a call to the introspection endpoint (10s).
+	DefaultIntrospectionTimeout = 10 * time.Second
+)
+
+// IntrospectionConfiguration struct.
+type IntrospectionConfiguration struct {
+	Endpoint         string
+	ClientID         string
+	ClientSecret     string
+	Client           *http.Client
+	CacheTTL         time.Duration
+	NegativeCacheTTL time.Duration
+	CacheSize        int
+	Timeout          time.Duration
+	// RolesClaim is the member of the introspection response containing the roles,
+	// DefaultJWTRolesClaim is used when empty.
+	RolesClaim string
+}
+
+type introspectionEntry struct {
+	principal *Principal
+	err       error
+	expiresAt time.Time
+}
+
+// IntrospectionValidator validates opaque tokens with the OAuth 2.0 Token Introspection
+// endpoint (RFC 7662), the responses are cached.
+type IntrospectionValidator struct {
+	cfg    *IntrospectionConfiguration
+	mtx    sync.RWMutex
+	cache  map[string]introspectionEntry
+	flight flightGroup
+	now    func() time.Time
+}
+
+// NewIntrospectionValidator constructor.
+func NewIntrospectionValidator(cfg *IntrospectionConfiguration) *IntrospectionValidator {
+	// The defaults are applied on a copy, the configuration may be shared.
+	c := *cfg
+	cfg = &c
+
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{
+			Timeout: 10 * time.Second,
+		}
+	}
+
+	if cfg.CacheTTL == 0 {
+		cfg.CacheTTL = DefaultIntrospectionCacheTTL
+	}
+
+	if cfg.NegativeCacheTTL == 0 {
+		cfg.NegativeCacheTTL = DefaultIntrospectionNegativeCacheTTL
+	}
+
+	if cfg.CacheSize == 0 {
+		cfg.CacheSize = DefaultIntrospectionCacheSize
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultIntrospectionTimeout
+	}
+
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = DefaultJWTRolesClaim
+	}
+
+	return &IntrospectionValidator{
+		cfg:   cfg,
+		cache: make(map[string]introspectionEntry),
+		now:   time.Now,
+	}
+}
+
+// Introspection provider validates the opaque bearer token of the request.
+func Introspection(cfg *IntrospectionConfiguration) Provider {
+	return Named("introspection", Bearer(NewIntrospectionValidator(cfg).Validate))
+}
+
+// Validate implements TokenValidatorFunc.
+func (v *IntrospectionValidator) Validate(ctx context.Context, token string) (*Principal, error) {
+	// The tokens are not kept in memory, only their hash.
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	if entry, ok := v.lookup(key); ok {
+		return entry.principal, entry.err
+	}
+
+	return v.flight.Do(key, func() (*Principal, error) {
+		if entry, ok := v.lookup(key); ok {
+			return entry.principal, entry.err
+		}
+
+		// The call is shared by the waiting requests, it must not be cancelled by the first one.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), v.cfg.Timeout)
+		defer cancel()
+
+		principal, ttl, err := v.introspect(ctx, token)
+		if ttl > 0 {
+			v.store(key, introspectionEntry{
+				principal: principal,
+				err:       err,
+				expiresAt: v.now().Add(ttl),
+			})
+		}
+
+		return principal, err
+	})
+}
+
+func (v *IntrospectionValidator) lookup(key string) (introspectionEntry, bool) {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+
+	entry, ok := v.cache[key]
+	if !ok || !v.now().Before(entry.expiresAt) {
+		return introspectionEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (v *IntrospectionValidator) store(key string, entry introspectionEntry) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if len(v.cache) >= v.cfg.CacheSize {
+		now := v.now()
+
+		for k, e := range v.cache {
+			if !now.Before(e.expiresAt) {
+				delete(v.cache, k)
+			}
+		}
+
+		// Still full of valid entries, start over rather than growing without bound.
+		if len(v.cache) >= v.cfg.CacheSize {
+			v.cache = make(map[string]introspectionEntry)
+		}
+	}
+
+	v.cache[key] = entry
+}
+
+// introspect calls the introspection endpoint and returns the principal and the cache TTL,
+// the errors of the endpoint itself are ErrUnavailable and are not cached.
+func (v *IntrospectionValidator) introspect(ctx context.Context, token string) (*Principal, time.Duration, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.cfg.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: failed to create introspection request: %w", ErrUnavailable, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if v.cfg.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(v.cfg.ClientID), url.QueryEscape(v.cfg.ClientSecret))
+	}
+
+	resp, err := v.cfg.Client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: failed to call introspection endpoint: %w", ErrUnavailable, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error().Err(err).Msg("Close introspection response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("%w: failed to call introspection endpoint: unexpected status %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	claims := make(map[string]interface{})
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, 0, fmt.Errorf("%w: failed to decode introspection response: %w", ErrUnavailable, err)
+	}
+
+	if active, _ := claims["active"].(bool); !active {
+		return nil, v.cfg.NegativeCacheTTL, NewInvalidTokenError(ErrInvalidCredentials, "The access token is inactive")
+	}
+
+	ttl := v.cfg.CacheTTL
+
+	exp, ok, err := numericDate(claims, "exp")
+	if err != nil {
+		return nil, v.cfg.NegativeCacheTTL, NewInvalidTokenError(ErrInvalidCredentials, "The access token expiration is invalid")
+	}
+
+	// The exp member is optional in the introspection responses (RFC 7662).
+	if ok {
+		remaining := exp.Sub(v.now())
+		if remaining <= 0 {
+			return nil, v.cfg.NegativeCacheTTL, NewInvalidTokenError(ErrExpiredCredentials, "The access token expired")
+		}
+
+		if remaining < ttl {
+			ttl = remaining
+		}
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		subject, _ = claims["username"].(string)
+	}
+
+	return &Principal{
+		Subject: subject,
+		Scopes:  scopesFromClaim(claims["scope"]),
+		Roles:   scopesFromClaim(claims[v.cfg.RolesClaim]),
+		Claims:  claims,
+	}, ttl, nil
+}
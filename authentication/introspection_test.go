@@ -0,0 +1,320 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func introspectionServer(t *testing.T, calls *int32, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		time.Sleep(delay)
+
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "api" || secret != "p%40ss" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "access_token", r.PostForm.Get("token_type_hint"))
+
+		resp := map[string]interface{}{
+			"active": false,
+		}
+
+		switch r.PostForm.Get("token") {
+		case "valid":
+			resp = map[string]interface{}{
+				"active":    true,
+				"sub":       "john",
+				"scope":     "read write",
+				"roles":     []string{"admin"},
+				"groups":    []string{"ops"},
+				"client_id": "app",
+				"exp":       time.Now().Add(time.Hour).Unix(),
+			}
+		case "short":
+			resp = map[string]interface{}{
+				"active":   true,
+				"username": "jane",
+				"exp":      time.Now().Add(time.Second).Unix(),
+			}
+		case "expired":
+			resp = map[string]interface{}{
+				"active": true,
+				"exp":    time.Now().Add(-time.Second).Unix(),
+			}
+		case "error":
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		case "garbage":
+			_, _ = w.Write([]byte("<html>"))
+
+			return
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func newTestIntrospectionValidator(endpoint string) *IntrospectionValidator {
+	return NewIntrospectionValidator(&IntrospectionConfiguration{
+		Endpoint:     endpoint,
+		ClientID:     "api",
+		ClientSecret: "p@ss",
+	})
+}
+
+func TestIntrospectionValidator(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	validator := newTestIntrospectionValidator(server.URL)
+
+	principal, err := validator.Validate(context.Background(), "valid")
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+	assert.Equal(t, []string{"read", "write"}, principal.Scopes)
+	assert.True(t, principal.HasScope("write"))
+	assert.Equal(t, []string{"admin"}, principal.Roles)
+	assert.Equal(t, "app", principal.Claim("client_id"))
+
+	_, err = validator.Validate(context.Background(), "valid")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	principal, err = validator.Validate(context.Background(), "short")
+	assert.NoError(t, err)
+	assert.Equal(t, "jane", principal.Subject)
+
+	_, err = validator.Validate(context.Background(), "expired")
+	assert.ErrorIs(t, err, ErrExpiredCredentials)
+}
+
+func TestIntrospectionValidatorCacheHonorsExp(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	validator := newTestIntrospectionValidator(server.URL)
+
+	now := time.Now()
+	validator.now = func() time.Time {
+		return now
+	}
+
+	_, err := validator.Validate(context.Background(), "short")
+	assert.NoError(t, err)
+
+	now = now.Add(2 * time.Second)
+
+	_, err = validator.Validate(context.Background(), "short")
+	assert.ErrorIs(t, err, ErrExpiredCredentials)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionValidatorNegativeCache(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	validator := newTestIntrospectionValidator(server.URL)
+
+	for i := 0; i < 3; i++ {
+		_, err := validator.Validate(context.Background(), "revoked")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionValidatorDoesNotCacheEndpointErrors(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	validator := newTestIntrospectionValidator(server.URL)
+
+	for i := 0; i < 2; i++ {
+		_, err := validator.Validate(context.Background(), "error")
+		assert.ErrorIs(t, err, ErrUnavailable)
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	_, err := validator.Validate(context.Background(), "garbage")
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials)
+
+	validator = NewIntrospectionValidator(&IntrospectionConfiguration{
+		Endpoint: server.URL,
+	})
+
+	_, err = validator.Validate(context.Background(), "valid")
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestIntrospectionValidatorRolesClaim(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	cfg := &IntrospectionConfiguration{
+		Endpoint:     server.URL,
+		ClientID:     "api",
+		ClientSecret: "p@ss",
+		RolesClaim:   "groups",
+	}
+
+	principal, err := NewIntrospectionValidator(cfg).Validate(context.Background(), "valid")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ops"}, principal.Roles)
+	assert.True(t, principal.HasRole("ops"))
+}
+
+func TestIntrospectionValidatorSingleflight(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 50*time.Millisecond)
+	defer server.Close()
+
+	validator := newTestIntrospectionValidator(server.URL)
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			principal, err := validator.Validate(context.Background(), "valid")
+			assert.NoError(t, err)
+			assert.Equal(t, "john", principal.Subject)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionValidatorSingleflightDetachedFromCaller(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 50*time.Millisecond)
+	defer server.Close()
+
+	validator := newTestIntrospectionValidator(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		_, _ = validator.Validate(ctx, "valid")
+	}()
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, time.Second, time.Millisecond)
+
+	// The first caller goes away while the other callers wait for the shared call.
+	cancel()
+
+	principal, err := validator.Validate(context.Background(), "valid")
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionValidatorCacheSize(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	validator := NewIntrospectionValidator(&IntrospectionConfiguration{
+		Endpoint:     server.URL,
+		ClientID:     "api",
+		ClientSecret: "p@ss",
+		CacheSize:    2,
+	})
+
+	for _, token := range []string{"a", "b", "c", "d"} {
+		_, err := validator.Validate(context.Background(), token)
+		assert.Error(t, err)
+	}
+
+	assert.LessOrEqual(t, len(validator.cache), 2)
+}
+
+func TestIntrospection(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	provider := Introspection(&IntrospectionConfiguration{
+		Endpoint:     server.URL,
+		ClientID:     "api",
+		ClientSecret: "p@ss",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("Authorization", "Bearer valid")
+
+	principal, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+}
+
+func TestHandlerWithUnavailableIntrospection(t *testing.T) {
+	var calls int32
+
+	server := introspectionServer(t, &calls, 0)
+	defer server.Close()
+
+	handler := Handler(&Configuration{
+		Realm: "Test",
+	}, Introspection(&IntrospectionConfiguration{
+		Endpoint:     server.URL,
+		ClientID:     "api",
+		ClientSecret: "p@ss",
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("the request must not be authenticated")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("Authorization", "Bearer error")
+
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Empty(t, w.Header().Get("WWW-Authenticate"), "the token is not rejected")
+}
@@ -0,0 +1,370 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// DefaultJWKSRefreshInterval sets the interval of the background refresh of the JWKS (15m).
+	DefaultJWKSRefreshInterval = 15 * time.Minute
+
+	// DefaultJWKSMinRefreshInterval sets the minimum interval between two refreshes
+	// triggered by an unknown key ID (1m).
+	DefaultJWKSMinRefreshInterval = time.Minute
+)
+
+const (
+	// maxJWKSSize is the maximum size of a JWKS response body (1MB).
+	maxJWKSSize = 1 << 20
+
+	// minRSAKeyBits is the minimum size of the RSA moduli of the JWKS.
+	minRSAKeyBits = 2048
+)
+
+// ErrKeyNotFound is returned by a KeySet when the key ID is unknown.
+var ErrKeyNotFound = errors.New("key not found")
+
+// KeySet interface.
+type KeySet interface {
+	// Key returns the crypto.PublicKey (or the []byte secret for HMAC) of the key ID.
+	Key(ctx context.Context, kid string) (interface{}, error)
+}
+
+// AlgorithmKey is a key restricted to a JWS algorithm, ParseJWKS returns it for the keys
+// with an "alg" parameter and the JWTValidator rejects the tokens signed with another algorithm.
+type AlgorithmKey struct {
+	Algorithm string
+	Key       interface{}
+}
+
+// StaticKeySet is a KeySet of keys indexed by key ID.
+type StaticKeySet map[string]interface{}
+
+// Key implements KeySet.
+func (s StaticKeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// JWKSConfiguration struct.
+type JWKSConfiguration struct {
+	URL                string
+	Client             *http.Client
+	RefreshInterval    time.Duration
+	MinRefreshInterval time.Duration
+}
+
+// JWKS is a KeySet fetched from a JSON Web Key Set URL (RFC 7517), the keys are cached
+// and refreshed in background, an unknown key ID triggers a rate limited refresh.
+type JWKS struct {
+	cfg       *JWKSConfiguration
+	mtx       sync.RWMutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+	refresh   sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewJWKS constructor, the background refresh is stopped by Close.
+func NewJWKS(cfg *JWKSConfiguration) *JWKS {
+	// The defaults are applied on a copy, the configuration may be shared.
+	c := *cfg
+	cfg = &c
+
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{
+			Timeout: 10 * time.Second,
+		}
+	}
+
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = DefaultJWKSRefreshInterval
+	}
+
+	if cfg.MinRefreshInterval <= 0 {
+		cfg.MinRefreshInterval = DefaultJWKSMinRefreshInterval
+	}
+
+	k := &JWKS{
+		cfg:  cfg,
+		keys: make(map[string]interface{}),
+		done: make(chan struct{}),
+	}
+
+	go k.loop()
+
+	return k
+}
+
+func (k *JWKS) loop() {
+	ticker := time.NewTicker(k.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := k.Refresh(context.Background()); err != nil {
+				log.Error().Err(err).Str("url", k.cfg.URL).Msg("JWKS refresh failed")
+			}
+		case <-k.done:
+			return
+		}
+	}
+}
+
+// Close stops the background refresh.
+func (k *JWKS) Close() error {
+	k.closeOnce.Do(func() {
+		close(k.done)
+	})
+
+	return nil
+}
+
+// Key implements KeySet.
+func (k *JWKS) Key(ctx context.Context, kid string) (interface{}, error) {
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+
+	if err := k.refreshIfStale(ctx); err != nil {
+		return nil, err
+	}
+
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+func (k *JWKS) lookup(kid string) (interface{}, bool) {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+
+	key, ok := k.keys[kid]
+
+	return key, ok
+}
+
+// refreshIfStale refreshes the keys unless they have been fetched less than
+// MinRefreshInterval ago, concurrent callers wait for the same refresh.
+func (k *JWKS) refreshIfStale(ctx context.Context) error {
+	k.refresh.Lock()
+	defer k.refresh.Unlock()
+
+	k.mtx.RLock()
+	fetchedAt := k.fetchedAt
+	k.mtx.RUnlock()
+
+	if time.Since(fetchedAt) < k.cfg.MinRefreshInterval {
+		return nil
+	}
+
+	return k.fetch(ctx)
+}
+
+// Refresh fetches the JWKS URL and replaces the cached keys.
+func (k *JWKS) Refresh(ctx context.Context) error {
+	k.refresh.Lock()
+	defer k.refresh.Unlock()
+
+	return k.fetch(ctx)
+}
+
+func (k *JWKS) fetch(ctx context.Context) error {
+	k.mtx.Lock()
+	k.fetchedAt = time.Now()
+	k.mtx.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.cfg.URL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := k.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error().Err(err).Msg("Close JWKS response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	keys, err := ParseJWKS(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return err
+	}
+
+	k.mtx.Lock()
+	k.keys = keys
+	k.mtx.Unlock()
+
+	return nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS decodes a JSON Web Key Set and returns the keys indexed by key ID,
+// keys not used for signature, with an unsupported type or with an RSA modulus
+// under 2048 bits are ignored. The keys with an "alg" parameter are returned as AlgorithmKey.
+func ParseJWKS(r io.Reader) (map[string]interface{}, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.key()
+		if err != nil {
+			log.Debug().Err(err).Str("kid", jwk.Kid).Msg("JWKS key ignored")
+
+			continue
+		}
+
+		if jwk.Alg != "" {
+			key = AlgorithmKey{
+				Algorithm: jwk.Alg,
+				Key:       key,
+			}
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (jwk jsonWebKey) key() (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+
+		if n.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key of %d bits is too small", n.BitLen())
+		}
+
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		key := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+
+		// ECDH validates that the point is on the curve.
+		if _, err := key.ECDH(); err != nil {
+			return nil, fmt.Errorf("invalid EC key: %w", err)
+		}
+
+		return key, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		k, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil {
+			return nil, fmt.Errorf("invalid oct key: %w", err)
+		}
+
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key parameter: %w", err)
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeJWK(kid string, key interface{}) map[string]string {
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": kid, "n": b64(k.N.Bytes()), "e": b64(big.NewInt(int64(k.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": kid, "crv": k.Curve.Params().Name, "x": b64(k.X.Bytes()), "y": b64(k.Y.Bytes())}
+	case ed25519.PublicKey:
+		return map[string]string{"kty": "OKP", "kid": kid, "crv": "Ed25519", "x": b64(k)}
+	case []byte:
+		return map[string]string{"kty": "oct", "kid": kid, "k": b64(k)}
+	}
+
+	return nil
+}
+
+func jwksHandler(t *testing.T, keys map[string]interface{}) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		set := []map[string]string{}
+
+		for kid, key := range keys {
+			set = append(set, encodeJWK(kid, key))
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"keys": set}))
+	})
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	keys, err := ParseJWKS(strings.NewReader(`{"keys":[
+		{"kty":"RSA","kid":"rsa","use":"sig","n":"` + base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()) + `","e":"AQAB"},
+		{"kty":"RSA","kid":"rs384","alg":"RS384","n":"` + base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()) + `","e":"AQAB"},
+		{"kty":"RSA","kid":"small","n":"` + base64.RawURLEncoding.EncodeToString(smallKey.N.Bytes()) + `","e":"AQAB"},
+		{"kty":"EC","kid":"ec","crv":"P-384","x":"` + base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()) + `","y":"` + base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()) + `"},
+		{"kty":"OKP","kid":"ed","crv":"Ed25519","x":"` + base64.RawURLEncoding.EncodeToString(edPublicKey) + `"},
+		{"kty":"oct","kid":"hs","k":"c2VjcmV0"},
+		{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"},
+		{"kty":"EC","kid":"bad-ec","crv":"P-256","x":"AQAB","y":"AQAB"},
+		{"kty":"OKP","kid":"x25519","crv":"X25519","x":"AQAB"},
+		{"kty":"foo","kid":"foo"}
+	]}`))
+	assert.NoError(t, err)
+
+	assert.Len(t, keys, 5)
+	assert.True(t, rsaKey.PublicKey.Equal(keys["rsa"]))
+	assert.Equal(t, AlgorithmKey{Algorithm: "RS384", Key: &rsaKey.PublicKey}, keys["rs384"])
+	assert.NotContains(t, keys, "small", "the RSA keys under 2048 bits are ignored")
+	assert.True(t, ecKey.PublicKey.Equal(keys["ec"]))
+	assert.True(t, edPublicKey.Equal(keys["ed"]))
+	assert.Equal(t, []byte("secret"), keys["hs"])
+
+	_, err = ParseJWKS(strings.NewReader(`bad`))
+	assert.Error(t, err)
+}
+
+func TestJWKSRotation(t *testing.T) {
+	var (
+		mtx   sync.Mutex
+		keys  = map[string]interface{}{"v1": []byte("secret-v1")}
+		calls int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		jwksHandler(t, keys).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(&JWKSConfiguration{
+		URL:                server.URL,
+		MinRefreshInterval: time.Millisecond,
+	})
+	defer jwks.Close()
+
+	key, err := jwks.Key(context.Background(), "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret-v1"), key)
+
+	_, err = jwks.Key(context.Background(), "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	mtx.Lock()
+	keys = map[string]interface{}{"v2": []byte("secret-v2")}
+	mtx.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	key, err = jwks.Key(context.Background(), "v2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret-v2"), key)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	_, err = jwks.Key(context.Background(), "v1")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestJWKSMinRefreshInterval(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		jwksHandler(t, map[string]interface{}{}).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(&JWKSConfiguration{
+		URL: server.URL,
+	})
+	defer jwks.Close()
+
+	for i := 0; i < 5; i++ {
+		_, err := jwks.Key(context.Background(), "unknown")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestJWKSBackgroundRefresh(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		jwksHandler(t, map[string]interface{}{"v1": []byte("secret")}).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(&JWKSConfiguration{
+		URL:             server.URL,
+		RefreshInterval: 5 * time.Millisecond,
+	})
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) >= 2
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, jwks.Close())
+	assert.NoError(t, jwks.Close())
+
+	key, ok := jwks.lookup("v1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("secret"), key)
+}
+
+func TestJWKSWithBadStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(&JWKSConfiguration{
+		URL: server.URL,
+	})
+	defer jwks.Close()
+
+	_, err := jwks.Key(context.Background(), "v1")
+	assert.Error(t, err)
+}
+
+func TestNewJWKSDefaults(t *testing.T) {
+	cfg := &JWKSConfiguration{
+		URL:             "http://127.0.0.1/jwks.json",
+		RefreshInterval: -time.Second,
+	}
+
+	jwks := NewJWKS(cfg)
+	defer jwks.Close()
+
+	assert.Equal(t, DefaultJWKSRefreshInterval, jwks.cfg.RefreshInterval)
+	assert.Equal(t, DefaultJWKSMinRefreshInterval, jwks.cfg.MinRefreshInterval)
+	assert.NotNil(t, jwks.cfg.Client)
+
+	assert.Equal(t, -time.Second, cfg.RefreshInterval, "the configuration is not modified")
+	assert.Nil(t, cfg.Client)
+}
+
+func TestJWKSWithTooLargeBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"keys":[],"padding":"` + strings.Repeat("a", maxJWKSSize) + `"}`))
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(&JWKSConfiguration{
+		URL: server.URL,
+	})
+	defer jwks.Close()
+
+	err := jwks.Refresh(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,377 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	// Register the hash functions used by the JWS algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// DefaultJWTAlgorithms are the asymmetric algorithms allowed when JWTConfiguration.Algorithms is empty,
+// HMAC algorithms must be enabled explicitly.
+var DefaultJWTAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// DefaultJWTScopeClaim is the claim containing the space separated scopes (RFC 8693).
+const DefaultJWTScopeClaim = "scope"
+
+// DefaultJWTRolesClaim is the claim containing the roles (RFC 9068).
+const DefaultJWTRolesClaim = "roles"
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// JWTConfiguration struct.
+type JWTConfiguration struct {
+	// KeySet used to resolve the signing key of the "kid" header, use a *JWKS for key rotation.
+	KeySet     KeySet
+	Issuer     string
+	Audience   string
+	Algorithms []string
+	ClockSkew  time.Duration
+	ScopeClaim string
+	RolesClaim string
+	// AllowMissingExpiry accepts the tokens without "exp" claim, they are rejected by default.
+	AllowMissingExpiry bool
+}
+
+// JWTValidator validates JSON Web Tokens (RFC 7519) signed with a JWS compact serialization.
+type JWTValidator struct {
+	cfg        *JWTConfiguration
+	algorithms map[string]bool
+	now        func() time.Time
+}
+
+// NewJWTValidator constructor.
+func NewJWTValidator(cfg *JWTConfiguration) *JWTValidator {
+	// The defaults are applied on a copy, the configuration may be shared.
+	c := *cfg
+	cfg = &c
+
+	if len(cfg.Algorithms) == 0 {
+		cfg.Algorithms = DefaultJWTAlgorithms
+	}
+
+	if cfg.ScopeClaim == "" {
+		cfg.ScopeClaim = DefaultJWTScopeClaim
+	}
+
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = DefaultJWTRolesClaim
+	}
+
+	algorithms := make(map[string]bool, len(cfg.Algorithms))
+
+	for _, alg := range cfg.Algorithms {
+		algorithms[alg] = true
+	}
+
+	return &JWTValidator{
+		cfg:        cfg,
+		algorithms: algorithms,
+		now:        time.Now,
+	}
+}
+
+// JWT provider validates the JWT bearer token of the request.
+func JWT(cfg *JWTConfiguration) Provider {
+	return Named("jwt", Bearer(NewJWTValidator(cfg).Validate))
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Validate implements TokenValidatorFunc.
+func (v *JWTValidator) Validate(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token is malformed")
+	}
+
+	var header jwtHeader
+
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token is malformed")
+	}
+
+	if !v.algorithms[header.Alg] {
+		return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token algorithm is not allowed")
+	}
+
+	key, err := v.cfg.KeySet.Key(ctx, header.Kid)
+	if err != nil {
+		return nil, NewInvalidTokenError(fmt.Errorf("%w: %w", ErrInvalidCredentials, err), "The access token signing key is unknown")
+	}
+
+	if k, ok := key.(AlgorithmKey); ok {
+		if k.Algorithm != header.Alg {
+			return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token algorithm does not match the signing key")
+		}
+
+		key = k.Key
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token is malformed")
+	}
+
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, NewInvalidTokenError(fmt.Errorf("%w: %w", ErrInvalidCredentials, err), "The access token signature is invalid")
+	}
+
+	claims := make(map[string]interface{})
+
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, NewInvalidTokenError(ErrInvalidCredentials, "The access token is malformed")
+	}
+
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	subject, _ := claims["sub"].(string)
+
+	return &Principal{
+		Subject: subject,
+		Scopes:  scopesFromClaim(claims[v.cfg.ScopeClaim]),
+		Roles:   scopesFromClaim(claims[v.cfg.RolesClaim]),
+		Claims:  claims,
+	}, nil
+}
+
+func (v *JWTValidator) validateClaims(claims map[string]interface{}) error {
+	now := v.now()
+
+	exp, ok, err := numericDate(claims, "exp")
+	if err != nil {
+		return NewInvalidTokenError(ErrInvalidCredentials, "The access token expiration is invalid")
+	}
+
+	if !ok && !v.cfg.AllowMissingExpiry {
+		return NewInvalidTokenError(ErrInvalidCredentials, "The access token has no expiration")
+	}
+
+	if ok && now.After(exp.Add(v.cfg.ClockSkew)) {
+		return NewInvalidTokenError(ErrExpiredCredentials, "The access token expired")
+	}
+
+	nbf, ok, err := numericDate(claims, "nbf")
+	if err != nil {
+		return NewInvalidTokenError(ErrInvalidCredentials, "The access token not before is invalid")
+	}
+
+	if ok && now.Add(v.cfg.ClockSkew).Before(nbf) {
+		return NewInvalidTokenError(ErrInvalidCredentials, "The access token is not yet valid")
+	}
+
+	if v.cfg.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+			return NewInvalidTokenError(ErrInvalidCredentials, "The access token issuer is invalid")
+		}
+	}
+
+	if v.cfg.Audience != "" && !hasAudience(claims["aud"], v.cfg.Audience) {
+		return NewInvalidTokenError(ErrInvalidCredentials, "The access token audience is invalid")
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("failed to decode segment: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("failed to unmarshal segment: %w", err)
+	}
+
+	return nil
+}
+
+// maxNumericDate is the last second of the year 9999, the larger dates are rejected.
+const maxNumericDate = 253402300799
+
+var errInvalidNumericDate = errors.New("invalid numeric date")
+
+// numericDate returns the NumericDate claim (RFC 7519) and whether it is present,
+// the claims that are not a number of seconds in [0, maxNumericDate] are invalid.
+func numericDate(claims map[string]interface{}, name string) (time.Time, bool, error) {
+	value, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	n, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, true, errInvalidNumericDate
+	}
+
+	f, err := n.Float64()
+	if err != nil || math.IsNaN(f) || f < 0 || f > maxNumericDate {
+		return time.Time{}, true, errInvalidNumericDate
+	}
+
+	sec, frac := math.Modf(f)
+
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))), true, nil
+}
+
+func hasAudience(value interface{}, audience string) bool {
+	switch aud := value.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, item := range aud {
+			if s, ok := item.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// scopesFromClaim supports space separated values and arrays of values (ex: "scp" claim).
+func scopesFromClaim(value interface{}) []string {
+	switch scope := value.(type) {
+	case string:
+		return strings.Fields(scope)
+	case []interface{}:
+		scopes := make([]string, 0, len(scope))
+
+		for _, item := range scope {
+			if s, ok := item.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+
+		return scopes
+	}
+
+	return nil
+}
+
+func hashForAlgorithm(alg string) crypto.Hash {
+	switch alg[len(alg)-3:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+// verifySignature checks the JWS signature, the key type must match the algorithm family.
+func verifySignature(alg string, key interface{}, signed []byte, signature []byte) error {
+	if alg == "EdDSA" {
+		k, ok := key.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(k, signed, signature) {
+			return errInvalidSignature
+		}
+
+		return nil
+	}
+
+	if len(alg) != 5 {
+		return errInvalidSignature
+	}
+
+	hash := hashForAlgorithm(alg)
+
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "RS", "PS":
+		k, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errInvalidSignature
+		}
+
+		if alg[0] == 'R' {
+			return rsa.VerifyPKCS1v15(k, hash, digest, signature) // nolint: wrapcheck
+		}
+
+		return rsa.VerifyPSS(k, hash, digest, signature, &rsa.PSSOptions{ // nolint: wrapcheck
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+		})
+	case "ES":
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errInvalidSignature
+		}
+
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size || k.Curve.Params().BitSize != ecdsaBitSize(alg) {
+			return errInvalidSignature
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errInvalidSignature
+		}
+
+		return nil
+	case "HS":
+		k, ok := key.([]byte)
+		if !ok {
+			return errInvalidSignature
+		}
+
+		mac := hmac.New(hash.New, k)
+		mac.Write(signed)
+
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errInvalidSignature
+		}
+
+		return nil
+	default:
+		return errInvalidSignature
+	}
+}
+
+func ecdsaBitSize(alg string) int {
+	switch alg {
+	case "ES384":
+		return 384
+	case "ES512":
+		return 521
+	default:
+		return 256
+	}
+}
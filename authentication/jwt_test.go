@@ -0,0 +1,349 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signToken(t *testing.T, alg string, kid string, key interface{}, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	assert.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		hash := hashForAlgorithm(alg)
+		h := hash.New()
+		h.Write([]byte(signed))
+
+		if alg[0] == 'P' {
+			signature, err = rsa.SignPSS(rand.Reader, k, hash, h.Sum(nil), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, h.Sum(nil))
+		}
+
+		assert.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		hash := hashForAlgorithm(alg)
+		h := hash.New()
+		h.Write([]byte(signed))
+
+		r, s, err := ecdsa.Sign(rand.Reader, k, h.Sum(nil))
+		assert.NoError(t, err)
+
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(k, []byte(signed))
+	case []byte:
+		mac := hmac.New(crypto.SHA256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":   "john",
+		"iss":   "https://auth.example.com",
+		"aud":   []string{"api", "other"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nbf":   time.Now().Add(-time.Minute).Unix(),
+		"scope": "read write",
+	}
+}
+
+func TestJWTValidator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	secret := []byte("secret")
+
+	validator := NewJWTValidator(&JWTConfiguration{
+		KeySet: StaticKeySet{
+			"rsa":   &rsaKey.PublicKey,
+			"ec":    &ecKey.PublicKey,
+			"ec384": &ec384Key.PublicKey,
+			"ed":    edPublicKey,
+			"hs":    secret,
+		},
+		Issuer:     "https://auth.example.com",
+		Audience:   "api",
+		Algorithms: []string{"RS256", "PS512", "ES256", "ES384", "EdDSA", "HS256"},
+	})
+
+	for _, item := range []struct {
+		alg string
+		kid string
+		key interface{}
+	}{
+		{"RS256", "rsa", rsaKey},
+		{"PS512", "rsa", rsaKey},
+		{"ES256", "ec", ecKey},
+		{"ES384", "ec384", ec384Key},
+		{"EdDSA", "ed", edKey},
+		{"HS256", "hs", secret},
+	} {
+		principal, err := validator.Validate(context.Background(), signToken(t, item.alg, item.kid, item.key, validClaims()))
+		assert.NoError(t, err, item.alg)
+
+		if assert.NotNil(t, principal, item.alg) {
+			assert.Equal(t, "john", principal.Subject)
+			assert.Equal(t, []string{"read", "write"}, principal.Scopes)
+			assert.Equal(t, "https://auth.example.com", principal.Claim("iss"))
+		}
+	}
+}
+
+func TestJWTValidatorRejectsInvalidTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	validator := NewJWTValidator(&JWTConfiguration{
+		KeySet: StaticKeySet{
+			"rsa": &rsaKey.PublicKey,
+			"rs384": AlgorithmKey{
+				Algorithm: "RS384",
+				Key:       &rsaKey.PublicKey,
+			},
+		},
+		Issuer:    "https://auth.example.com",
+		Audience:  "api",
+		ClockSkew: 30 * time.Second,
+	})
+
+	claims := func(key string, value interface{}) map[string]interface{} {
+		c := validClaims()
+		c[key] = value
+
+		return c
+	}
+
+	without := func(key string) map[string]interface{} {
+		c := validClaims()
+		delete(c, key)
+
+		return c
+	}
+
+	for name, item := range map[string]struct {
+		token       string
+		expected    error
+		description string
+	}{
+		"malformed": {
+			token:       "foo.bar",
+			expected:    ErrInvalidCredentials,
+			description: "The access token is malformed",
+		},
+		"bad header": {
+			token:       "!!.bar.baz",
+			expected:    ErrInvalidCredentials,
+			description: "The access token is malformed",
+		},
+		"hmac not allowed": {
+			token:       signToken(t, "HS256", "rsa", []byte("secret"), validClaims()),
+			expected:    ErrInvalidCredentials,
+			description: "The access token algorithm is not allowed",
+		},
+		"unknown kid": {
+			token:       signToken(t, "RS256", "unknown", rsaKey, validClaims()),
+			expected:    ErrKeyNotFound,
+			description: "The access token signing key is unknown",
+		},
+		"algorithm not matching the key": {
+			token:       signToken(t, "RS256", "rs384", rsaKey, validClaims()),
+			expected:    ErrInvalidCredentials,
+			description: "The access token algorithm does not match the signing key",
+		},
+		"bad signature": {
+			token:       signToken(t, "RS256", "rsa", otherKey, validClaims()),
+			expected:    ErrInvalidCredentials,
+			description: "The access token signature is invalid",
+		},
+		"expired": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, claims("exp", time.Now().Add(-time.Minute).Unix())),
+			expected:    ErrExpiredCredentials,
+			description: "The access token expired",
+		},
+		"missing expiration": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, without("exp")),
+			expected:    ErrInvalidCredentials,
+			description: "The access token has no expiration",
+		},
+		"invalid expiration": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, claims("exp", "tomorrow")),
+			expected:    ErrInvalidCredentials,
+			description: "The access token expiration is invalid",
+		},
+		"far future not before": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, claims("nbf", 1e19)),
+			expected:    ErrInvalidCredentials,
+			description: "The access token not before is invalid",
+		},
+		"not before after 2262": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, claims("nbf", 9999999999)),
+			expected:    ErrInvalidCredentials,
+			description: "The access token is not yet valid",
+		},
+		"not before": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, claims("nbf", time.Now().Add(time.Minute).Unix())),
+			expected:    ErrInvalidCredentials,
+			description: "The access token is not yet valid",
+		},
+		"bad issuer": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, claims("iss", "https://evil.example.com")),
+			expected:    ErrInvalidCredentials,
+			description: "The access token issuer is invalid",
+		},
+		"bad audience": {
+			token:       signToken(t, "RS256", "rsa", rsaKey, claims("aud", "other")),
+			expected:    ErrInvalidCredentials,
+			description: "The access token audience is invalid",
+		},
+	} {
+		_, err := validator.Validate(context.Background(), item.token)
+		assert.ErrorIs(t, err, item.expected, name)
+
+		var authErr *Error
+		if assert.True(t, errors.As(err, &authErr), name) {
+			assert.Equal(t, ErrorCodeInvalidToken, authErr.Code, name)
+			assert.Equal(t, item.description, authErr.Description, name)
+		}
+	}
+}
+
+func TestNewJWTValidatorDoesNotModifyConfiguration(t *testing.T) {
+	cfg := &JWTConfiguration{
+		KeySet: StaticKeySet{},
+	}
+
+	validator := NewJWTValidator(cfg)
+
+	assert.Empty(t, cfg.Algorithms)
+	assert.Empty(t, cfg.ScopeClaim)
+	assert.Empty(t, cfg.RolesClaim)
+	assert.Equal(t, DefaultJWTAlgorithms, validator.cfg.Algorithms)
+}
+
+func TestJWTValidatorClockSkew(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	validator := NewJWTValidator(&JWTConfiguration{
+		KeySet: StaticKeySet{
+			"rsa": &rsaKey.PublicKey,
+		},
+		ClockSkew: time.Minute,
+	})
+
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(-30 * time.Second).Unix()
+	claims["scope"] = []string{"read"}
+	claims["roles"] = []string{"admin"}
+
+	principal, err := validator.Validate(context.Background(), signToken(t, "RS256", "rsa", rsaKey, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read"}, principal.Scopes)
+	assert.True(t, principal.HasRole("admin"))
+}
+
+func TestJWTValidatorAllowMissingExpiry(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	validator := NewJWTValidator(&JWTConfiguration{
+		KeySet: StaticKeySet{
+			"rsa": &rsaKey.PublicKey,
+		},
+		AllowMissingExpiry: true,
+	})
+
+	claims := validClaims()
+	delete(claims, "exp")
+
+	principal, err := validator.Validate(context.Background(), signToken(t, "RS256", "rsa", rsaKey, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+}
+
+func TestNumericDate(t *testing.T) {
+	date, ok, err := numericDate(map[string]interface{}{"exp": json.Number("1700000000.5")}, "exp")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1700000000, 500000000), date)
+
+	_, ok, err = numericDate(map[string]interface{}{}, "exp")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = numericDate(map[string]interface{}{"exp": json.Number("-1")}, "exp")
+	assert.Error(t, err)
+}
+
+func TestJWTWithJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(jwksHandler(t, map[string]interface{}{"rsa": &rsaKey.PublicKey}))
+	defer server.Close()
+
+	jwks := NewJWKS(&JWKSConfiguration{
+		URL: server.URL,
+	})
+	defer jwks.Close()
+
+	provider := JWT(&JWTConfiguration{
+		KeySet: jwks,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, "RS256", "rsa", rsaKey, validClaims()))
+
+	principal, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+}
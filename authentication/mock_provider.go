@@ -10,16 +10,25 @@ type MockProvider struct {
 	mock.Mock
 }
 
-// Validate provides a mock function with given fields: r
-func (_m *MockProvider) Validate(r *http.Request) bool {
+// Authenticate provides a mock function with given fields: r
+func (_m *MockProvider) Authenticate(r *http.Request) (*Principal, error) {
 	ret := _m.Called(r)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(*http.Request) bool); ok {
+	var r0 *Principal
+	if rf, ok := ret.Get(0).(func(*http.Request) *Principal); ok {
 		r0 = rf(r)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Principal)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
@@ -0,0 +1,58 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"sync"
+	"time"
+)
+
+// NonceStore interface used for replay protection.
+type NonceStore interface {
+	// Use records the nonce until expiresAt and returns false if it has already been used.
+	Use(nonce string, expiresAt time.Time) bool
+}
+
+// MemoryNonceStore is an in-memory NonceStore, expired nonces are purged on use.
+type MemoryNonceStore struct {
+	mtx      sync.Mutex
+	nonces   map[string]time.Time
+	purgedAt time.Time
+	now      func() time.Time
+}
+
+// NewMemoryNonceStore constructor.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		nonces: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Use implements NonceStore.
+func (s *MemoryNonceStore) Use(nonce string, expiresAt time.Time) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+
+	if now.Sub(s.purgedAt) > time.Minute {
+		for n, e := range s.nonces {
+			if now.After(e) {
+				delete(s.nonces, n)
+			}
+		}
+
+		s.purgedAt = now
+	}
+
+	if e, ok := s.nonces[nonce]; ok && !now.After(e) {
+		return false
+	}
+
+	s.nonces[nonce] = expiresAt
+
+	return true
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+)
+
+type key int
+
+const (
+	contextKey key = iota
+)
+
+// Principal is the authenticated caller.
+type Principal struct {
+	// Provider is the name of the provider which authenticated the principal, see Named.
+	Provider string
+	Subject  string
+	Scopes   []string
+	Roles    []string
+	Claims   map[string]interface{}
+}
+
+// HasScope returns true if the principal has been granted the scope.
+func (p *Principal) HasScope(scope string) bool {
+	return containsString(p.Scopes, scope)
+}
+
+// HasRole returns true if the principal has the role.
+func (p *Principal) HasRole(role string) bool {
+	return containsString(p.Roles, role)
+}
+
+// Claim returns the value of the claim, or nil if the principal does not have it.
+func (p *Principal) Claim(name string) interface{} {
+	if p.Claims == nil {
+		return nil
+	}
+
+	return p.Claims[name]
+}
+
+// ToContext add Principal to Context.
+func ToContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey, principal)
+}
+
+// FromContext returns Principal from Context.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(contextKey).(*Principal)
+
+	return principal, ok && principal != nil
+}
+
+// SubjectFromContext returns the subject of the Principal from Context.
+func SubjectFromContext(ctx context.Context) string {
+	if principal, ok := FromContext(ctx); ok {
+		return principal.Subject
+	}
+
+	return ""
+}
+
+// ScopesFromContext returns the scopes of the Principal from Context.
+func ScopesFromContext(ctx context.Context) []string {
+	if principal, ok := FromContext(ctx); ok {
+		return principal.Scopes
+	}
+
+	return nil
+}
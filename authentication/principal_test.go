@@ -0,0 +1,51 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal(t *testing.T) {
+	p := &Principal{
+		Subject: "john",
+		Scopes:  []string{"read", "write"},
+		Roles:   []string{"admin"},
+		Claims: map[string]interface{}{
+			"email": "john@example.com",
+		},
+	}
+
+	assert.True(t, p.HasScope("read"))
+	assert.False(t, p.HasScope("admin"))
+	assert.True(t, p.HasRole("admin"))
+	assert.False(t, p.HasRole("read"))
+	assert.Equal(t, "john@example.com", p.Claim("email"))
+	assert.Nil(t, p.Claim("name"))
+	assert.Nil(t, (&Principal{}).Claim("name"))
+}
+
+func TestPrincipalContext(t *testing.T) {
+	ctx := context.Background()
+
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, "", SubjectFromContext(ctx))
+	assert.Nil(t, ScopesFromContext(ctx))
+
+	ctx = ToContext(ctx, &Principal{
+		Subject: "john",
+		Scopes:  []string{"read"},
+	})
+
+	principal, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "john", principal.Subject)
+	assert.Equal(t, "john", SubjectFromContext(ctx))
+	assert.Equal(t, []string{"read"}, ScopesFromContext(ctx))
+}
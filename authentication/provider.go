@@ -4,11 +4,131 @@
 
 package authentication
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrNoCredentials is returned by a Provider when the request does not carry
+	// credentials for its strategy, the next provider of a Chain is then tried.
+	ErrNoCredentials = errors.New("no credentials")
+
+	// ErrInvalidCredentials is returned by a Provider when the credentials are rejected.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrExpiredCredentials is returned by a Provider when the credentials are expired.
+	ErrExpiredCredentials = errors.New("expired credentials")
+
+	// ErrUnavailable is returned by a Provider when the credentials cannot be verified
+	// (ex: the introspection endpoint is down), Handler responds 503 without challenge.
+	ErrUnavailable = errors.New("authentication unavailable")
+)
 
 // Provider interface.
 //
 //go:generate mockery -case=underscore -inpkg -name=Provider
 type Provider interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// ProviderFunc type is an adapter to allow the use of ordinary functions as Provider.
+type ProviderFunc func(r *http.Request) (*Principal, error)
+
+// Authenticate calls f(r).
+func (f ProviderFunc) Authenticate(r *http.Request) (*Principal, error) {
+	return f(r)
+}
+
+// Validator interface is the legacy boolean provider contract.
+type Validator interface {
 	Validate(r *http.Request) bool
 }
+
+// FromValidator adapts a Validator to the Provider interface,
+// the returned Principal has no subject.
+func FromValidator(validator Validator) Provider {
+	return ProviderFunc(func(r *http.Request) (*Principal, error) {
+		if !validator.Validate(r) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return &Principal{}, nil
+	})
+}
+
+// Chain providers with first-match semantics: providers are tried in order and the
+// first one which does not return ErrNoCredentials decides the authentication result.
+// Without credentials, the error of the first provider is returned for its challenge.
+func Chain(providers ...Provider) Provider {
+	return ProviderFunc(func(r *http.Request) (*Principal, error) {
+		var first error
+
+		for _, provider := range providers {
+			principal, err := provider.Authenticate(r)
+			if errors.Is(err, ErrNoCredentials) {
+				if first == nil {
+					first = err
+				}
+
+				continue
+			}
+
+			return principal, err
+		}
+
+		if first == nil {
+			first = ErrNoCredentials
+		}
+
+		return nil, first
+	})
+}
+
+// Named provider sets the name of the provider on the Principal and on the errors,
+// the name is used by the metrics and the audit logs. The built-in providers are named
+// after their strategy ("jwt", "basic", "api_key", ...), the outermost name wins.
+func Named(name string, provider Provider) Provider {
+	return ProviderFunc(func(r *http.Request) (*Principal, error) {
+		principal, err := provider.Authenticate(r)
+		if err != nil {
+			return nil, &providerError{
+				provider: name,
+				err:      err,
+			}
+		}
+
+		if principal == nil {
+			return nil, nil
+		}
+
+		// The principal may be cached by the provider.
+		named := *principal
+		named.Provider = name
+
+		return &named, nil
+	})
+}
+
+type providerError struct {
+	provider string
+	err      error
+}
+
+func (e *providerError) Error() string {
+	return e.err.Error()
+}
+
+func (e *providerError) Unwrap() error {
+	return e.err
+}
+
+// providerFromError returns the name of the provider which returned the error.
+func providerFromError(err error) string {
+	var perr *providerError
+	if errors.As(err, &perr) {
+		return perr.provider
+	}
+
+	return ""
+}
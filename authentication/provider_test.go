@@ -0,0 +1,103 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validatorFunc func(r *http.Request) bool
+
+func (f validatorFunc) Validate(r *http.Request) bool {
+	return f(r)
+}
+
+func TestFromValidator(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	principal, err := FromValidator(validatorFunc(func(r *http.Request) bool {
+		return true
+	})).Authenticate(req)
+	assert.NoError(t, err)
+	assert.NotNil(t, principal)
+
+	principal, err = FromValidator(validatorFunc(func(r *http.Request) bool {
+		return false
+	})).Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Nil(t, principal)
+}
+
+func TestChain(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	skipped := ProviderFunc(func(r *http.Request) (*Principal, error) {
+		return nil, ErrNoCredentials
+	})
+
+	matched := ProviderFunc(func(r *http.Request) (*Principal, error) {
+		return &Principal{Subject: "john"}, nil
+	})
+
+	rejected := ProviderFunc(func(r *http.Request) (*Principal, error) {
+		return nil, ErrInvalidCredentials
+	})
+
+	principal, err := Chain(skipped, matched, rejected).Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+
+	principal, err = Chain(skipped, rejected, matched).Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Nil(t, principal)
+
+	_, err = Chain(skipped, skipped).Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	_, err = Chain().Authenticate(req)
+	assert.True(t, errors.Is(err, ErrNoCredentials))
+}
+
+func TestNamed(t *testing.T) {
+	principal := &Principal{Subject: "john"}
+
+	provider := Named("foo", ProviderFunc(func(r *http.Request) (*Principal, error) {
+		if r.Header.Get("Authorization") == "" {
+			return nil, ErrNoCredentials
+		}
+
+		return principal, nil
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	_, err := provider.Authenticate(req)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+	assert.Equal(t, "foo", providerFromError(err))
+	assert.Equal(t, "no credentials", err.Error())
+
+	req.Header.Set("Authorization", "Bearer foo")
+
+	p, err := provider.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", p.Provider)
+	assert.Equal(t, "john", p.Subject)
+	assert.Empty(t, principal.Provider)
+
+	// The outermost name wins.
+	p, err = Named("bar", provider).Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", p.Provider)
+
+	_, err = Named("bar", Basic(nil)).Authenticate(httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+	assert.Equal(t, "bar", providerFromError(err))
+
+	assert.Empty(t, providerFromError(ErrNoCredentials))
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// errFlightPanicked is returned to the callers waiting on a call that panicked.
+var errFlightPanicked = errors.New("authentication: the shared call panicked")
+
+type flightCall struct {
+	wg        sync.WaitGroup
+	principal *Principal
+	err       error
+}
+
+// flightGroup deduplicates concurrent calls sharing the same key,
+// the callers waiting on an in-flight call get its result. When the call panics,
+// the waiting callers get an error and the panic is propagated to the caller running it.
+type flightGroup struct {
+	mtx   sync.Mutex
+	calls map[string]*flightCall
+}
+
+func (g *flightGroup) Do(key string, fn func() (*Principal, error)) (*Principal, error) {
+	g.mtx.Lock()
+
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	if c, ok := g.calls[key]; ok {
+		g.mtx.Unlock()
+		c.wg.Wait()
+
+		return c.principal, c.err
+	}
+
+	c := &flightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mtx.Unlock()
+
+	completed := false
+
+	defer func() {
+		var recovered interface{}
+
+		if !completed {
+			recovered = recover()
+
+			c.principal = nil
+			c.err = fmt.Errorf("%w: %v", errFlightPanicked, recovered)
+		}
+
+		g.mtx.Lock()
+		delete(g.calls, key)
+		g.mtx.Unlock()
+
+		c.wg.Done()
+
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+
+	c.principal, c.err = fn()
+	completed = true
+
+	return c.principal, c.err
+}
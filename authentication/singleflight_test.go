@@ -0,0 +1,59 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package authentication
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlightGroupWithPanic(t *testing.T) {
+	g := flightGroup{}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		assert.PanicsWithValue(t, "boom", func() {
+			_, _ = g.Do("key", func() (*Principal, error) {
+				close(started)
+				<-release
+
+				panic("boom")
+			})
+		})
+	}()
+
+	<-started
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		principal, err := g.Do("key", func() (*Principal, error) {
+			return &Principal{Subject: "john"}, nil
+		})
+
+		assert.ErrorIs(t, err, errFlightPanicked)
+		assert.Nil(t, principal)
+	}()
+
+	// Let the waiter join the in-flight call.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	wg.Wait()
+
+	principal, err := g.Do("key", func() (*Principal, error) {
+		return &Principal{Subject: "john"}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "john", principal.Subject)
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// labelNamePattern matches the portable Prometheus label names, the names starting
+// with "__" are reserved.
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// BuildTime of the binary, the Go toolchain does not record it:
+//
+//	go build -ldflags "-X github.com/euskadi31/go-server.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var BuildTime string
+
+// BuildDependency is a module dependency of the binary.
+type BuildDependency struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+	Replace string `json:"replace,omitempty"`
+}
+
+// BuildInfo of the binary, the VCS fields are empty when the binary is built without -buildvcs.
+type BuildInfo struct {
+	Path         string            `json:"path"`
+	Version      string            `json:"version"`
+	Revision     string            `json:"revision,omitempty"`
+	RevisionTime string            `json:"revision_time,omitempty"`
+	Modified     bool              `json:"modified"`
+	BuildTime    string            `json:"build_time,omitempty"`
+	GoVersion    string            `json:"go_version"`
+	Dependencies []BuildDependency `json:"dependencies,omitempty"`
+	Fields       map[string]string `json:"fields,omitempty"`
+}
+
+// BuildInfoOptions of Router.EnableBuildInfo.
+type BuildInfoOptions struct {
+	// Fields added by the application (ex: environment, region), they are exposed by the
+	// info endpoint and as labels of the build_info gauge.
+	Fields map[string]string
+	// Metrics options of the build_info gauge, only the Namespace, the ConstLabels and the
+	// Registerer are used.
+	Metrics metrics.Options
+}
+
+// ReadBuildInfo returns the build info of the running binary.
+func ReadBuildInfo() BuildInfo {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{
+			Version:   "(unknown)",
+			BuildTime: BuildTime,
+			GoVersion: runtime.Version(),
+		}
+	}
+
+	return newBuildInfo(bi)
+}
+
+func newBuildInfo(bi *debug.BuildInfo) BuildInfo {
+	info := BuildInfo{
+		Path:         bi.Main.Path,
+		Version:      bi.Main.Version,
+		BuildTime:    BuildTime,
+		GoVersion:    bi.GoVersion,
+		Dependencies: make([]BuildDependency, 0, len(bi.Deps)),
+	}
+
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.RevisionTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	for _, dep := range bi.Deps {
+		d := BuildDependency{
+			Path:    dep.Path,
+			Version: dep.Version,
+			Sum:     dep.Sum,
+		}
+
+		if dep.Replace != nil {
+			d.Replace = dep.Replace.Path + "@" + dep.Replace.Version
+		}
+
+		info.Dependencies = append(info.Dependencies, d)
+	}
+
+	return info
+}
+
+// newBuildInfoGauge returns the build_info gauge, always 1, labeled with the build info
+// and the custom fields. The fields must be valid label names and must not replace
+// the build info labels or the const labels.
+func newBuildInfoGauge(info BuildInfo, opts metrics.Options) (prometheus.Gauge, error) {
+	labels := prometheus.Labels{
+		"path":       info.Path,
+		"version":    info.Version,
+		"revision":   info.Revision,
+		"go_version": info.GoVersion,
+	}
+
+	for name, value := range opts.ConstLabels {
+		if _, ok := labels[name]; ok {
+			return nil, fmt.Errorf("the %s const label is reserved by the build_info metric", name)
+		}
+
+		labels[name] = value
+	}
+
+	for name, value := range info.Fields {
+		if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return nil, fmt.Errorf("the %s build info field is not a valid label name", name)
+		}
+
+		if _, ok := labels[name]; ok {
+			return nil, fmt.Errorf("the %s build info field is reserved", name)
+		}
+
+		labels[name] = value
+	}
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   opts.Namespace,
+		Name:        "build_info",
+		Help:        "A metric with a constant '1' value labeled by the build info of the binary.",
+		ConstLabels: labels,
+	})
+
+	gauge.Set(1)
+
+	return gauge, nil
+}
+
+// registerBuildInfoGauge registers the gauge in opts.Registerer, a gauge already registered
+// by another router is kept.
+func registerBuildInfoGauge(gauge prometheus.Gauge, opts metrics.Options) error {
+	registerer := opts.Registerer
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	if err := registerer.Register(gauge); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to register the build_info metric: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBuildInfo(t *testing.T) {
+	BuildTime = "2026-10-19T08:00:00Z"
+	defer func() {
+		BuildTime = ""
+	}()
+
+	info := newBuildInfo(&debug.BuildInfo{
+		GoVersion: "go1.27.1",
+		Main: debug.Module{
+			Path:    "github.com/acme/api",
+			Version: "v1.2.0",
+		},
+		Deps: []*debug.Module{
+			{
+				Path:    "github.com/gorilla/mux",
+				Version: "v1.8.1",
+				Sum:     "h1:TuBL49tXwgrFYWhqrNgrUNEY92u81SPhu7sTdzQEiWY=",
+			},
+			{
+				Path:    "github.com/rs/zerolog",
+				Version: "v1.34.0",
+				Replace: &debug.Module{
+					Path:    "github.com/acme/zerolog",
+					Version: "v1.34.1",
+				},
+			},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "4bf92f3577b34da6a3ce929d0e0e4736"},
+			{Key: "vcs.time", Value: "2026-10-18T20:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	})
+
+	assert.Equal(t, "github.com/acme/api", info.Path)
+	assert.Equal(t, "v1.2.0", info.Version)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", info.Revision)
+	assert.Equal(t, "2026-10-18T20:00:00Z", info.RevisionTime)
+	assert.True(t, info.Modified)
+	assert.Equal(t, "2026-10-19T08:00:00Z", info.BuildTime)
+	assert.Equal(t, "go1.27.1", info.GoVersion)
+	assert.Equal(t, []BuildDependency{
+		{
+			Path:    "github.com/gorilla/mux",
+			Version: "v1.8.1",
+			Sum:     "h1:TuBL49tXwgrFYWhqrNgrUNEY92u81SPhu7sTdzQEiWY=",
+		},
+		{
+			Path:    "github.com/rs/zerolog",
+			Version: "v1.34.0",
+			Replace: "github.com/acme/zerolog@v1.34.1",
+		},
+	}, info.Dependencies)
+}
+
+func TestReadBuildInfo(t *testing.T) {
+	info := ReadBuildInfo()
+
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
+
+func TestRouterEnableBuildInfo(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.EnableBuildInfo(BuildInfoOptions{
+		Fields: map[string]string{
+			"environment": "production",
+		},
+		Metrics: metrics.Options{
+			Namespace:  "app",
+			Registerer: registry,
+		},
+	})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/info", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	info := BuildInfo{}
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, "production", info.Fields["environment"])
+
+	expected := `
+		# HELP app_build_info A metric with a constant '1' value labeled by the build info of the binary.
+		# TYPE app_build_info gauge
+		app_build_info{environment="production",go_version="` + runtime.Version() + `",path="` + info.Path + `",revision="` + info.Revision + `",version="` + info.Version + `"} 1
+	`
+
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "app_build_info"))
+
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, authenticated(httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil)))
+
+	assert.Contains(t, w.Body.String(), `"version":"`+info.Version+`"`, "the detailed health check uses the build version")
+}
+
+func TestRouterEnableBuildInfoKeepsHealthCheckVersion(t *testing.T) {
+	router := NewRouter()
+
+	router.SetHealthCheckVersion("1.2.0", "1.2.0-4bf92f3")
+
+	err := router.EnableBuildInfo(BuildInfoOptions{
+		Metrics: metrics.Options{
+			Registerer: prometheus.NewRegistry(),
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.0", router.version.Load().version)
+}
+
+func TestRouterEnableBuildInfoWithInvalidField(t *testing.T) {
+	for name, expected := range map[string]string{
+		"__reserved":  "the __reserved build info field is not a valid label name",
+		"invalid-key": "the invalid-key build info field is not a valid label name",
+		"version":     "the version build info field is reserved",
+		"revision":    "the revision build info field is reserved",
+		"path":        "the path build info field is reserved",
+		"go_version":  "the go_version build info field is reserved",
+	} {
+		registry := prometheus.NewRegistry()
+
+		router := NewRouter()
+
+		err := router.EnableBuildInfo(BuildInfoOptions{
+			Fields: map[string]string{
+				name: "foo",
+			},
+			Metrics: metrics.Options{
+				Registerer: registry,
+			},
+		})
+		assert.EqualError(t, err, expected, name)
+
+		count, err := testutil.GatherAndCount(registry)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count, name)
+	}
+}
+
+func TestRouterEnableBuildInfoWithReservedConstLabel(t *testing.T) {
+	router := NewRouter()
+
+	err := router.EnableBuildInfo(BuildInfoOptions{
+		Metrics: metrics.Options{
+			ConstLabels: prometheus.Labels{
+				"version": "foo",
+			},
+			Registerer: prometheus.NewRegistry(),
+		},
+	})
+	assert.EqualError(t, err, "the version const label is reserved by the build_info metric")
+}
@@ -51,16 +51,20 @@ var (
 
 // Configuration struct.
 type Configuration struct {
-	HTTP              *HTTPConfiguration
-	HTTPS             *HTTPSConfiguration
-	ShutdownTimeout   time.Duration
-	WriteTimeout      time.Duration
-	ReadTimeout       time.Duration
-	ReadHeaderTimeout time.Duration
-	IdleTimeout       time.Duration
-	Profiling         bool
-	Metrics           bool
-	HealthCheck       bool
+	HTTP                *HTTPConfiguration
+	HTTPS               *HTTPSConfiguration
+	Admin               *HTTPConfiguration
+	ShutdownTimeout     time.Duration
+	ShutdownDelay       time.Duration
+	WriteTimeout        time.Duration
+	ReadTimeout         time.Duration
+	ReadHeaderTimeout   time.Duration
+	IdleTimeout         time.Duration
+	Profiling           bool
+	Metrics             bool
+	BuildInfo           bool
+	HealthCheck         bool
+	HealthCheckInterval time.Duration
 }
 
 // ConfigurationWithDefault return Configuration with default parameters.
@@ -109,6 +113,8 @@ func (c Configuration) IsEnabled(protocol string) bool {
 		return c.HTTP != nil && c.HTTP.IsEnabled()
 	case "https":
 		return c.HTTPS != nil && c.HTTPS.IsEnabled()
+	case "admin":
+		return c.Admin != nil && c.Admin.IsEnabled()
 	default:
 		return false
 	}
@@ -32,6 +32,14 @@ func TestConfigurationIsEnabled(t *testing.T) {
 
 	assert.True(t, c.IsEnabled("http"))
 	assert.True(t, c.IsEnabled("https"))
+	assert.False(t, c.IsEnabled("admin"))
+
+	c.Admin = &HTTPConfiguration{
+		Host: "127.0.0.1",
+		Port: 9090,
+	}
+
+	assert.True(t, c.IsEnabled("admin"))
 }
 
 func TestHTTPConfigurationAddr(t *testing.T) {
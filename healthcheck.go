@@ -5,7 +5,44 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HealthCheckMediaType of the health check responses
+// (https://datatracker.ietf.org/doc/html/draft-inadarei-api-health-check).
+const HealthCheckMediaType = "application/health+json"
+
+// DefaultHealthCheckTimeout is the timeout of a health check when HealthCheckOptions.Timeout is zero (5s).
+var DefaultHealthCheckTimeout = 5 * time.Second
+
+// Health check groups served by the probe endpoints.
+const (
+	HealthGroupLiveness  = "liveness"
+	HealthGroupReadiness = "readiness"
+	HealthGroupStartup   = "startup"
+)
+
+// DefaultHealthCheckGroups of a check registered without groups, the liveness probe
+// must not depend on the external services.
+var DefaultHealthCheckGroups = []string{HealthGroupReadiness, HealthGroupStartup}
+
+// HealthStatus of a health check.
+type HealthStatus string
+
+// HealthStatus values.
+const (
+	HealthStatusPass HealthStatus = "pass"
+	HealthStatusWarn HealthStatus = "warn"
+	HealthStatusFail HealthStatus = "fail"
 )
 
 // HealthCheckHandler type.
@@ -23,16 +60,260 @@ func (f HealthCheckHandlerFunc) Check() bool {
 	return f()
 }
 
-// HealthCheckResponse struct.
+// HealthChecker is a health check returning a detailed result, it must return when the context is done.
+//
+//go:generate mockery -case=underscore -inpkg -name=HealthChecker
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) HealthCheckResult
+}
+
+// HealthCheckerFunc handler.
+type HealthCheckerFunc func(ctx context.Context) HealthCheckResult
+
+// CheckHealth calls f(ctx).
+func (f HealthCheckerFunc) CheckHealth(ctx context.Context) HealthCheckResult {
+	return f(ctx)
+}
+
+// HealthCheckOptions struct.
+type HealthCheckOptions struct {
+	// Timeout of the check, DefaultHealthCheckTimeout is used when zero.
+	Timeout time.Duration
+	// Groups of the check, DefaultHealthCheckGroups are used when empty.
+	// All the checks are run by the /health endpoint.
+	Groups []string
+	// NonCritical checks degrade the overall status to warn instead of fail
+	// when they fail, the HTTP status stays 200.
+	NonCritical bool
+}
+
+// HealthCheckResult is the result of a health check, Duration and Time are set by the processor.
+type HealthCheckResult struct {
+	Status        HealthStatus
+	Output        string
+	ObservedValue interface{}
+	ObservedUnit  string
+	Duration      time.Duration
+	Time          time.Time
+}
+
+// HealthCheckPass returns a passing result.
+func HealthCheckPass() HealthCheckResult {
+	return HealthCheckResult{
+		Status: HealthStatusPass,
+	}
+}
+
+// HealthCheckWarn returns a warning result with the output message.
+func HealthCheckWarn(output string) HealthCheckResult {
+	return HealthCheckResult{
+		Status: HealthStatusWarn,
+		Output: output,
+	}
+}
+
+// HealthCheckFail returns a failing result with the error as output.
+func HealthCheckFail(err error) HealthCheckResult {
+	return HealthCheckResult{
+		Status: HealthStatusFail,
+		Output: err.Error(),
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r HealthCheckResult) MarshalJSON() ([]byte, error) {
+	result := struct {
+		Status        HealthStatus `json:"status"`
+		Output        string       `json:"output,omitempty"`
+		ObservedValue interface{}  `json:"observedValue,omitempty"`
+		ObservedUnit  string       `json:"observedUnit,omitempty"`
+		Duration      string       `json:"duration,omitempty"`
+		Time          *time.Time   `json:"time,omitempty"`
+	}{
+		Status:        r.Status,
+		Output:        r.Output,
+		ObservedValue: r.ObservedValue,
+		ObservedUnit:  r.ObservedUnit,
+	}
+
+	if r.Duration > 0 {
+		result.Duration = r.Duration.String()
+	}
+
+	if !r.Time.IsZero() {
+		result.Time = &r.Time
+	}
+
+	return json.Marshal(result) // nolint: wrapcheck
+}
+
+// HealthCheckResponse struct, the checks are indexed by name.
 type HealthCheckResponse struct {
-	Status   bool            `json:"status"`
-	Services map[string]bool `json:"services"`
+	Status    HealthStatus                   `json:"status"`
+	Version   string                         `json:"version,omitempty"`
+	ReleaseID string                         `json:"releaseId,omitempty"`
+	Checks    map[string][]HealthCheckResult `json:"checks,omitempty"`
+}
+
+// Terse returns the response without the details, only the overall status is public.
+func (r HealthCheckResponse) Terse() HealthCheckResponse {
+	return HealthCheckResponse{
+		Status: r.Status,
+	}
 }
 
-func healthCheckProcessor(healthchecks map[string]HealthCheckHandler) HealthCheckResponse {
+// StatusCode returns the HTTP status of the response, a warning is healthy.
+func (r HealthCheckResponse) StatusCode() int {
+	if r.Status == HealthStatusFail {
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusOK
+}
+
+// addCheck adds the result of a check and updates the status with its impact.
+func (r *HealthCheckResponse) addCheck(name string, result HealthCheckResult, impact HealthStatus) {
+	if r.Checks == nil {
+		r.Checks = make(map[string][]HealthCheckResult)
+	}
+
+	r.Checks[name] = append(r.Checks[name], result)
+
+	r.Status = worstStatus(r.Status, impact)
+}
+
+// healthCheck is a registered health check.
+type healthCheck struct {
+	checker     HealthChecker
+	timeout     time.Duration
+	groups      map[string]struct{}
+	nonCritical bool
+	// removed is set when the check is removed or replaced, its running evaluations are not observed.
+	removed atomic.Bool
+}
+
+func newHealthCheck(checker HealthChecker, opts HealthCheckOptions) *healthCheck {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultHealthCheckTimeout
+	}
+
+	if len(opts.Groups) == 0 {
+		opts.Groups = DefaultHealthCheckGroups
+	}
+
+	groups := make(map[string]struct{}, len(opts.Groups))
+
+	for _, group := range opts.Groups {
+		groups[group] = struct{}{}
+	}
+
+	return &healthCheck{
+		checker:     checker,
+		timeout:     opts.Timeout,
+		groups:      groups,
+		nonCritical: opts.NonCritical,
+	}
+}
+
+// impact returns the contribution of the check status to the overall status,
+// a non critical failure is a warning.
+func (c *healthCheck) impact(status HealthStatus) HealthStatus {
+	if c.nonCritical && status == HealthStatusFail {
+		return HealthStatusWarn
+	}
+
+	return status
+}
+
+// inGroup reports whether the check belongs to the group, all the checks belong to the empty group.
+func (c *healthCheck) inGroup(group string) bool {
+	if group == "" {
+		return true
+	}
+
+	_, ok := c.groups[group]
+
+	return ok
+}
+
+// legacyHealthCheck adapts a HealthCheckHandler to a HealthChecker.
+func legacyHealthCheck(handler HealthCheckHandler) HealthChecker {
+	return HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		if handler.Check() {
+			return HealthCheckPass()
+		}
+
+		return HealthCheckResult{
+			Status: HealthStatusFail,
+			Output: "Health check failed",
+		}
+	})
+}
+
+// errHealthCheckTimeout is the cancellation cause of a check reaching its own timeout.
+var errHealthCheckTimeout = errors.New("health check timeout")
+
+// run the check under its timeout, a panic or a timeout fails the check. The result is
+// not ok when the check was aborted by the cancellation of ctx (ex: the probe disconnected),
+// it must not be observed as a failure of the check.
+func (c *healthCheck) run(ctx context.Context) (HealthCheckResult, bool) {
+	checkCtx, cancel := context.WithTimeoutCause(ctx, c.timeout, errHealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	ch := make(chan HealthCheckResult, 1)
+
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Error().Interface("panic", rec).Msg("Health check panicked")
+
+				ch <- HealthCheckFail(fmt.Errorf("panic: %v", rec))
+			}
+		}()
+
+		ch <- c.checker.CheckHealth(checkCtx)
+	}()
+
+	var result HealthCheckResult
+
+	select {
+	case result = <-ch:
+	case <-checkCtx.Done():
+		result = HealthCheckFail(fmt.Errorf("health check timed out after %s", c.timeout))
+	}
+
+	if ctx.Err() != nil && !errors.Is(context.Cause(checkCtx), errHealthCheckTimeout) {
+		return result, false
+	}
+
+	if result.Status == "" {
+		result.Status = HealthStatusPass
+	}
+
+	result.Duration = time.Since(start)
+	result.Time = start
+
+	return result, true
+}
+
+// worstStatus returns the most severe status.
+func worstStatus(a HealthStatus, b HealthStatus) HealthStatus {
+	switch {
+	case a == HealthStatusFail || b == HealthStatusFail:
+		return HealthStatusFail
+	case a == HealthStatusWarn || b == HealthStatusWarn:
+		return HealthStatusWarn
+	default:
+		return HealthStatusPass
+	}
+}
+
+func healthCheckProcessor(ctx context.Context, healthchecks map[string]*healthCheck) HealthCheckResponse {
 	response := HealthCheckResponse{
-		Status:   true,
-		Services: make(map[string]bool, len(healthchecks)),
+		Status: HealthStatusPass,
+		Checks: make(map[string][]HealthCheckResult, len(healthchecks)),
 	}
 
 	var (
@@ -40,26 +321,44 @@ func healthCheckProcessor(healthchecks map[string]HealthCheckHandler) HealthChec
 		mutex = &sync.Mutex{}
 	)
 
-	for name, handle := range healthchecks {
+	for name, check := range healthchecks {
 		wg.Add(1)
 
-		go func(n string, h HealthCheckHandler) {
+		go func(n string, c *healthCheck) {
 			defer wg.Done()
 
+			// The checks run concurrently, only the response update is serialized.
+			result, ok := c.run(ctx)
+
 			mutex.Lock()
 			defer mutex.Unlock()
 
-			s := h.Check()
-
-			response.Services[n] = s
+			if !ok {
+				// The aborted checks are left out of the response, it is not observed.
+				response.Status = worstStatus(response.Status, c.impact(HealthStatusFail))
 
-			if !s {
-				response.Status = false
+				return
 			}
-		}(name, handle)
+
+			response.Checks[n] = []HealthCheckResult{result}
+
+			response.Status = worstStatus(response.Status, c.impact(result.Status))
+		}(name, check)
 	}
 
 	wg.Wait()
 
 	return response
 }
+
+// writeHealthCheckResponse encodes the response with the health+json media type.
+func writeHealthCheckResponse(w http.ResponseWriter, resp HealthCheckResponse) {
+	w.Header().Set("Content-Type", HealthCheckMediaType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.StatusCode())
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Error().Err(err).Msg("json.NewEncoder().Encode() failed")
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+
+	server "github.com/euskadi31/go-server"
+)
+
+// DiskFree checks that the free space available to the process on the file system of the path
+// stays above the thresholds in bytes, a zero threshold is disabled.
+func DiskFree(path string, warn uint64, fail uint64) Checker {
+	return func(ctx context.Context) server.HealthCheckResult {
+		free, err := diskFree(path)
+		if err != nil {
+			return server.HealthCheckFail(fmt.Errorf("failed to stat %s: %w", path, err))
+		}
+
+		status, output := minThreshold(free, warn, fail)
+
+		return server.HealthCheckResult{
+			Status:        status,
+			Output:        output,
+			ObservedValue: free,
+			ObservedUnit:  "bytes",
+		}
+	}
+}
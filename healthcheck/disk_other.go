@@ -0,0 +1,15 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !darwin && !freebsd && !dragonfly
+
+package healthcheck
+
+import (
+	"errors"
+)
+
+func diskFree(path string) (uint64, error) {
+	return 0, errors.New("disk free space is not supported on this platform")
+}
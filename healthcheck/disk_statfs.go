@@ -0,0 +1,21 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin || freebsd || dragonfly
+
+package healthcheck
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func diskFree(path string) (uint64, error) {
+	var stat unix.Statfs_t
+
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err // nolint: wrapcheck
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil // nolint: gosec, unconvert
+}
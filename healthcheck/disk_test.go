@@ -0,0 +1,31 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	server "github.com/euskadi31/go-server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskFree(t *testing.T) {
+	dir := t.TempDir()
+
+	result := DiskFree(dir, 0, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+	assert.Equal(t, "bytes", result.ObservedUnit)
+
+	result = DiskFree(dir, math.MaxUint64, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusWarn, result.Status)
+
+	result = DiskFree(dir, 0, math.MaxUint64)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+
+	result = DiskFree(dir+"/missing", 0, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+}
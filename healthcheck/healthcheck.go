@@ -0,0 +1,71 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package healthcheck provides health checks of the common dependencies,
+// they implement both server.HealthChecker and server.HealthCheckHandler.
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	server "github.com/euskadi31/go-server"
+)
+
+// Checker is a health check usable with Router.AddHealthChecker and Router.AddHealthCheck.
+type Checker func(ctx context.Context) server.HealthCheckResult
+
+// CheckHealth implements server.HealthChecker.
+func (c Checker) CheckHealth(ctx context.Context) server.HealthCheckResult {
+	return c(ctx)
+}
+
+// Check implements server.HealthCheckHandler, a warning is healthy.
+func (c Checker) Check() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), server.DefaultHealthCheckTimeout)
+	defer cancel()
+
+	return c(ctx).Status != server.HealthStatusFail
+}
+
+// number is the type of the observed values compared to the thresholds.
+type number interface {
+	~int | ~int64 | ~uint64
+}
+
+// maxThreshold returns the status of a value which must stay below the thresholds,
+// a zero threshold is disabled.
+func maxThreshold[T number](value T, warn T, fail T) (server.HealthStatus, string) {
+	switch {
+	case fail > 0 && value >= fail:
+		return server.HealthStatusFail, fmt.Sprintf("%v exceeds the failure threshold of %v", value, fail)
+	case warn > 0 && value >= warn:
+		return server.HealthStatusWarn, fmt.Sprintf("%v exceeds the warning threshold of %v", value, warn)
+	default:
+		return server.HealthStatusPass, ""
+	}
+}
+
+// minThreshold returns the status of a value which must stay above the thresholds,
+// a zero threshold is disabled.
+func minThreshold[T number](value T, warn T, fail T) (server.HealthStatus, string) {
+	switch {
+	case fail > 0 && value < fail:
+		return server.HealthStatusFail, fmt.Sprintf("%v is below the failure threshold of %v", value, fail)
+	case warn > 0 && value < warn:
+		return server.HealthStatusWarn, fmt.Sprintf("%v is below the warning threshold of %v", value, warn)
+	default:
+		return server.HealthStatusPass, ""
+	}
+}
+
+// latency returns a passing result observing the latency in milliseconds.
+func latency(start time.Time) server.HealthCheckResult {
+	return server.HealthCheckResult{
+		Status:        server.HealthStatusPass,
+		ObservedValue: float64(time.Since(start).Microseconds()) / 1000,
+		ObservedUnit:  "ms",
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	server "github.com/euskadi31/go-server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker(t *testing.T) {
+	var _ server.HealthChecker = Checker(nil)
+	var _ server.HealthCheckHandler = Checker(nil)
+
+	var hasDeadline bool
+
+	pass := Checker(func(ctx context.Context) server.HealthCheckResult {
+		_, hasDeadline = ctx.Deadline()
+
+		return server.HealthCheckWarn("slow")
+	})
+
+	assert.True(t, pass.Check())
+	assert.True(t, hasDeadline, "Check runs under the default timeout")
+	assert.Equal(t, server.HealthStatusWarn, pass.CheckHealth(context.Background()).Status)
+
+	fail := Checker(func(ctx context.Context) server.HealthCheckResult {
+		return server.HealthCheckResult{Status: server.HealthStatusFail}
+	})
+
+	assert.False(t, fail.Check())
+}
+
+func TestMaxThreshold(t *testing.T) {
+	status, output := maxThreshold(5, 10, 20)
+	assert.Equal(t, server.HealthStatusPass, status)
+	assert.Empty(t, output)
+
+	status, output = maxThreshold(10, 10, 20)
+	assert.Equal(t, server.HealthStatusWarn, status)
+	assert.Equal(t, "10 exceeds the warning threshold of 10", output)
+
+	status, _ = maxThreshold(25, 10, 20)
+	assert.Equal(t, server.HealthStatusFail, status)
+
+	status, _ = maxThreshold(25, 0, 0)
+	assert.Equal(t, server.HealthStatusPass, status)
+}
+
+func TestMinThreshold(t *testing.T) {
+	status, _ := minThreshold(uint64(30), 20, 10)
+	assert.Equal(t, server.HealthStatusPass, status)
+
+	status, output := minThreshold(uint64(15), 20, 10)
+	assert.Equal(t, server.HealthStatusWarn, status)
+	assert.Equal(t, "15 is below the warning threshold of 20", output)
+
+	status, _ = minThreshold(time.Second, time.Minute, 2*time.Second)
+	assert.Equal(t, server.HealthStatusFail, status)
+}
+
+func TestLatency(t *testing.T) {
+	result := latency(time.Now().Add(-1500 * time.Microsecond))
+
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+	assert.Equal(t, "ms", result.ObservedUnit)
+	assert.GreaterOrEqual(t, result.ObservedValue, 1.5)
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	server "github.com/euskadi31/go-server"
+	"github.com/rs/zerolog/log"
+)
+
+// TCP checks that a connection to the address can be established.
+func TCP(addr string) Checker {
+	return func(ctx context.Context) server.HealthCheckResult {
+		start := time.Now()
+
+		var dialer net.Dialer
+
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return server.HealthCheckFail(fmt.Errorf("failed to dial %s: %w", addr, err))
+		}
+
+		if err := conn.Close(); err != nil {
+			log.Debug().Err(err).Str("addr", addr).Msg("Close health check connection")
+		}
+
+		return latency(start)
+	}
+}
+
+// HTTP checks that a GET request to the URL returns one of the expected status,
+// or a 2xx status when none is given. http.DefaultClient is used when client is nil.
+func HTTP(client *http.Client, url string, expectedStatus ...int) Checker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context) server.HealthCheckResult {
+		start := time.Now()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return server.HealthCheckFail(fmt.Errorf("failed to create request: %w", err))
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return server.HealthCheckFail(fmt.Errorf("failed to get %s: %w", url, err))
+		}
+
+		if err := resp.Body.Close(); err != nil {
+			log.Debug().Err(err).Str("url", url).Msg("Close health check response body")
+		}
+
+		if !isExpectedStatus(resp.StatusCode, expectedStatus) {
+			return server.HealthCheckFail(fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url))
+		}
+
+		return latency(start)
+	}
+}
+
+func isExpectedStatus(status int, expected []int) bool {
+	if len(expected) == 0 {
+		return status >= 200 && status < 300
+	}
+
+	for _, code := range expected {
+		if code == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DNS checks that the host resolves to at least one address, the number of addresses
+// is observed. net.DefaultResolver is used when resolver is nil.
+func DNS(resolver *net.Resolver, host string) Checker {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return func(ctx context.Context) server.HealthCheckResult {
+		addrs, err := resolver.LookupHost(ctx, host)
+		if err != nil {
+			return server.HealthCheckFail(fmt.Errorf("failed to resolve %s: %w", host, err))
+		}
+
+		if len(addrs) == 0 {
+			return server.HealthCheckFail(fmt.Errorf("no address found for %s", host))
+		}
+
+		return server.HealthCheckResult{
+			Status:        server.HealthStatusPass,
+			ObservedValue: len(addrs),
+		}
+	}
+}
+
+// TLSCertificate checks the expiry of the certificate served at the address, the check
+// warns when the certificate expires within warnBefore and fails within failBefore, an
+// expired certificate always fails.
+// The time until the expiry is observed in seconds. The config is optional.
+func TLSCertificate(addr string, config *tls.Config, warnBefore time.Duration, failBefore time.Duration) Checker {
+	return func(ctx context.Context) server.HealthCheckResult {
+		dialer := &tls.Dialer{
+			Config: config,
+		}
+
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return server.HealthCheckFail(fmt.Errorf("failed to dial %s: %w", addr, err))
+		}
+
+		defer func() {
+			if err := conn.Close(); err != nil {
+				log.Debug().Err(err).Str("addr", addr).Msg("Close health check connection")
+			}
+		}()
+
+		tlsConn, ok := conn.(*tls.Conn)
+		if !ok {
+			return server.HealthCheckFail(errors.New("not a TLS connection"))
+		}
+
+		certs := tlsConn.ConnectionState().PeerCertificates
+		if len(certs) == 0 {
+			return server.HealthCheckFail(fmt.Errorf("no certificate served by %s", addr))
+		}
+
+		remaining := time.Until(certs[0].NotAfter)
+
+		status, output := minThreshold(remaining, warnBefore, failBefore)
+
+		switch {
+		case remaining <= 0:
+			status = server.HealthStatusFail
+			output = fmt.Sprintf("The certificate of %s expired on %s", addr, certs[0].NotAfter.UTC().Format(time.RFC3339))
+		case status != server.HealthStatusPass:
+			output = fmt.Sprintf("The certificate of %s expires on %s", addr, certs[0].NotAfter.UTC().Format(time.RFC3339))
+		}
+
+		return server.HealthCheckResult{
+			Status:        status,
+			Output:        output,
+			ObservedValue: int64(remaining.Seconds()),
+			ObservedUnit:  "s",
+		}
+	}
+}
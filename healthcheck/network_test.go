@@ -0,0 +1,166 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	server "github.com/euskadi31/go-server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	addr := ln.Addr().String()
+
+	result := TCP(addr)(context.Background())
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+
+	assert.NoError(t, ln.Close())
+
+	result = TCP(addr)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+	assert.True(t, strings.HasPrefix(result.Output, "failed to dial "+addr))
+}
+
+func TestHTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	assert.Equal(t, server.HealthStatusPass, HTTP(nil, ts.URL)(context.Background()).Status)
+	assert.Equal(t, server.HealthStatusPass, HTTP(ts.Client(), ts.URL+"/down", http.StatusServiceUnavailable)(context.Background()).Status)
+
+	result := HTTP(ts.Client(), ts.URL+"/down")(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+	assert.Equal(t, "unexpected status 503 from "+ts.URL+"/down", result.Output)
+
+	result = HTTP(nil, ts.URL, http.StatusOK)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+
+	result = HTTP(nil, "://invalid")(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+}
+
+func TestDNS(t *testing.T) {
+	result := DNS(nil, "localhost")(context.Background())
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+	assert.GreaterOrEqual(t, result.ObservedValue, 1)
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, errors.New("no dns server")
+		},
+	}
+
+	result = DNS(resolver, "example.invalid")(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+	assert.True(t, strings.HasPrefix(result.Output, "failed to resolve example.invalid"))
+}
+
+func TestTLSCertificate(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Config.ErrorLog = log.New(io.Discard, "", 0)
+	ts.StartTLS()
+
+	defer ts.Close()
+
+	addr := ts.Listener.Addr().String()
+
+	config := &tls.Config{
+		InsecureSkipVerify: true, // nolint: gosec
+	}
+
+	result := TLSCertificate(addr, config, 30*24*time.Hour, 7*24*time.Hour)(context.Background())
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+	assert.Equal(t, "s", result.ObservedUnit)
+
+	notAfter := ts.Certificate().NotAfter
+
+	result = TLSCertificate(addr, config, time.Until(notAfter)+time.Hour, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusWarn, result.Status)
+	assert.Equal(t, "The certificate of "+addr+" expires on "+notAfter.UTC().Format(time.RFC3339), result.Output)
+
+	result = TLSCertificate(addr, config, 0, time.Until(notAfter)+time.Hour)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+
+	result = TLSCertificate(addr, nil, 0, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status, "the certificate is not trusted")
+}
+
+func TestTLSCertificateExpired(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	notAfter := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+	}, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{
+			{Certificate: [][]byte{der}, PrivateKey: key},
+		},
+	})
+	assert.NoError(t, err)
+
+	defer ln.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+
+	addr := ln.Addr().String()
+
+	result := TLSCertificate(addr, &tls.Config{
+		InsecureSkipVerify: true, // nolint: gosec
+	}, 0, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+	assert.Equal(t, "The certificate of "+addr+" expired on "+notAfter.UTC().Format(time.RFC3339), result.Output)
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"runtime"
+
+	server "github.com/euskadi31/go-server"
+)
+
+// Goroutines checks that the number of goroutines stays below the thresholds,
+// a zero threshold is disabled.
+func Goroutines(warn int, fail int) Checker {
+	return func(ctx context.Context) server.HealthCheckResult {
+		count := runtime.NumGoroutine()
+
+		status, output := maxThreshold(count, warn, fail)
+
+		return server.HealthCheckResult{
+			Status:        status,
+			Output:        output,
+			ObservedValue: count,
+		}
+	}
+}
+
+// Memory checks that the bytes of allocated heap objects stay below the thresholds,
+// a zero threshold is disabled.
+func Memory(warn uint64, fail uint64) Checker {
+	return func(ctx context.Context) server.HealthCheckResult {
+		var stats runtime.MemStats
+
+		runtime.ReadMemStats(&stats)
+
+		status, output := maxThreshold(stats.HeapAlloc, warn, fail)
+
+		return server.HealthCheckResult{
+			Status:        status,
+			Output:        output,
+			ObservedValue: stats.HeapAlloc,
+			ObservedUnit:  "bytes",
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+
+	server "github.com/euskadi31/go-server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoroutines(t *testing.T) {
+	result := Goroutines(0, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+	assert.Greater(t, result.ObservedValue, 0)
+
+	result = Goroutines(1, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusWarn, result.Status)
+
+	result = Goroutines(0, 1)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+}
+
+func TestMemory(t *testing.T) {
+	result := Memory(0, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+	assert.Equal(t, "bytes", result.ObservedUnit)
+
+	result = Memory(1, 0)(context.Background())
+	assert.Equal(t, server.HealthStatusWarn, result.Status)
+
+	result = Memory(0, 1)(context.Background())
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+}
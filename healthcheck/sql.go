@@ -0,0 +1,31 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	server "github.com/euskadi31/go-server"
+)
+
+// Pinger is implemented by *sql.DB and *sql.Conn.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// SQL checks the database connection with a ping, the latency is observed in milliseconds.
+func SQL(db Pinger) Checker {
+	return func(ctx context.Context) server.HealthCheckResult {
+		start := time.Now()
+
+		if err := db.PingContext(ctx); err != nil {
+			return server.HealthCheckFail(fmt.Errorf("failed to ping database: %w", err))
+		}
+
+		return latency(start)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	server "github.com/euskadi31/go-server"
+	"github.com/stretchr/testify/assert"
+)
+
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) PingContext(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestSQL(t *testing.T) {
+	result := SQL(pingerFunc(func(ctx context.Context) error {
+		return nil
+	}))(context.Background())
+
+	assert.Equal(t, server.HealthStatusPass, result.Status)
+	assert.Equal(t, "ms", result.ObservedUnit)
+
+	result = SQL(pingerFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}))(context.Background())
+
+	assert.Equal(t, server.HealthStatusFail, result.Status)
+	assert.Equal(t, "failed to ping database: connection refused", result.Output)
+}
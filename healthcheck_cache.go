@@ -0,0 +1,120 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// healthCheckCache evaluates the health checks on an interval in background,
+// the probes read the cached results and never run the checks.
+type healthCheckCache struct {
+	mtx     sync.RWMutex
+	results map[string]HealthCheckResult
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+func newHealthCheckCache() *healthCheckCache {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &healthCheckCache{
+		results: make(map[string]HealthCheckResult),
+		ctx:     ctx,
+		cancel:  cancel,
+		stopped: make(chan struct{}),
+	}
+}
+
+// healthCheckRunner runs the health checks, see Router.runHealthChecks.
+type healthCheckRunner func(ctx context.Context, healthchecks map[string]*healthCheck) HealthCheckResponse
+
+// loop evaluates the checks immediately and on each tick, and caches the results.
+func (c *healthCheckCache) loop(interval time.Duration, healthchecks func() map[string]*healthCheck, run healthCheckRunner) {
+	defer close(c.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.evaluate(healthchecks(), run)
+
+		select {
+		case <-ticker.C:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// evaluate runs the checks and caches their results, it is also called out of the loop
+// for the checks registered after the start.
+func (c *healthCheckCache) evaluate(healthchecks map[string]*healthCheck, run healthCheckRunner) {
+	resp := run(c.ctx, healthchecks)
+
+	// The evaluation aborted by close is incomplete, the previous results are kept.
+	if c.ctx.Err() != nil {
+		return
+	}
+
+	c.store(resp, healthchecks)
+}
+
+// store merges the results, an evaluation does not erase the results of the checks
+// it did not run. The results of the checks removed or replaced meanwhile are dropped.
+func (c *healthCheckCache) store(resp HealthCheckResponse, healthchecks map[string]*healthCheck) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for name, checks := range resp.Checks {
+		if check, ok := healthchecks[name]; !ok || check.removed.Load() {
+			continue
+		}
+
+		c.results[name] = checks[0]
+	}
+}
+
+// forget the result of a removed check.
+func (c *healthCheckCache) forget(name string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.results, name)
+}
+
+// response returns the cached results of the checks, a check without result is failing.
+func (c *healthCheckCache) response(healthchecks map[string]*healthCheck) HealthCheckResponse {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	resp := HealthCheckResponse{
+		Status: HealthStatusPass,
+	}
+
+	for name, check := range healthchecks {
+		result, ok := c.results[name]
+		if !ok {
+			result = HealthCheckResult{
+				Status: HealthStatusFail,
+				Output: "The health check has not been evaluated yet",
+			}
+		}
+
+		resp.addCheck(name, result, check.impact(result.Status))
+	}
+
+	return resp
+}
+
+// close stops the background evaluation and waits for the running one.
+func (c *healthCheckCache) close() {
+	c.cancel()
+
+	<-c.stopped
+}
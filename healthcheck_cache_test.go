@@ -0,0 +1,190 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterEnableBackgroundHealthCheck(t *testing.T) {
+	var calls int32
+
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		atomic.AddInt32(&calls, 1)
+
+		return HealthCheckPass()
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, router.EnableBackgroundHealthCheck(time.Hour))
+	defer router.DisableBackgroundHealthCheck()
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, time.Second, time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, authenticated(httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "redis")
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "the probes serve the cached results")
+}
+
+func TestRouterBackgroundHealthCheckInterval(t *testing.T) {
+	var calls int32
+
+	router := NewRouter()
+
+	err := router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			return HealthCheckWarn("slow")
+		}
+
+		return HealthCheckPass()
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, router.EnableBackgroundHealthCheck(20*time.Millisecond))
+
+	assert.Eventually(t, func() bool {
+		return router.healthCheck(context.Background(), "").Status == HealthStatusWarn
+	}, time.Second, 5*time.Millisecond)
+
+	router.DisableBackgroundHealthCheck()
+
+	n := atomic.LoadInt32(&calls)
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, n, atomic.LoadInt32(&calls), "the background evaluation is stopped")
+	assert.Equal(t, n+1, func() int32 {
+		router.healthCheck(context.Background(), "")
+
+		return atomic.LoadInt32(&calls)
+	}(), "the checks are run by the endpoints once disabled")
+}
+
+func TestRouterBackgroundHealthCheckWithNonCriticalFailure(t *testing.T) {
+	router := NewRouter()
+
+	err := router.AddHealthChecker("cache", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckFail(errors.New("connection refused"))
+	}), HealthCheckOptions{NonCritical: true})
+	assert.NoError(t, err)
+
+	assert.NoError(t, router.EnableBackgroundHealthCheck(time.Hour))
+	defer router.DisableBackgroundHealthCheck()
+
+	assert.Eventually(t, func() bool {
+		return router.healthCheck(context.Background(), "").Checks["cache"][0].Status == HealthStatusFail
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, HealthStatusWarn, router.healthCheck(context.Background(), "").Status)
+}
+
+func TestRouterBackgroundHealthCheckEvaluatesAddedCheck(t *testing.T) {
+	router := NewRouter()
+
+	assert.NoError(t, router.EnableBackgroundHealthCheck(time.Hour))
+	defer router.DisableBackgroundHealthCheck()
+
+	err := router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckPass()
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return router.healthCheck(context.Background(), "").Status == HealthStatusPass
+	}, time.Second, time.Millisecond, "the added check does not wait for the next tick")
+
+	err = router.ReplaceHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckWarn("slow")
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return router.healthCheck(context.Background(), "").Status == HealthStatusWarn
+	}, time.Second, time.Millisecond, "the replaced check does not wait for the next tick")
+
+	assert.NoError(t, router.RemoveHealthCheck("redis"))
+
+	cache := router.healthCache.Load()
+
+	cache.mtx.RLock()
+	defer cache.mtx.RUnlock()
+
+	assert.NotContains(t, cache.results, "redis")
+}
+
+func TestRouterEnableBackgroundHealthCheckWithInvalidInterval(t *testing.T) {
+	router := NewRouter()
+
+	assert.EqualError(t, router.EnableBackgroundHealthCheck(0), "the health check interval must be positive: 0s")
+	assert.Error(t, router.EnableBackgroundHealthCheck(-time.Second))
+	assert.Nil(t, router.healthCache.Load())
+}
+
+func TestHealthCheckCacheWithoutResult(t *testing.T) {
+	cache := newHealthCheckCache()
+
+	resp := cache.response(map[string]*healthCheck{
+		"redis": newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+			return HealthCheckPass()
+		}), HealthCheckOptions{}),
+	})
+
+	assert.Equal(t, HealthStatusFail, resp.Status)
+	assert.Equal(t, "The health check has not been evaluated yet", resp.Checks["redis"][0].Output)
+}
+
+func TestHealthCheckCacheCloseStopsRunningEvaluation(t *testing.T) {
+	cache := newHealthCheckCache()
+
+	started := make(chan struct{})
+
+	go cache.loop(time.Hour, func() map[string]*healthCheck {
+		return map[string]*healthCheck{
+			"slow": newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+				close(started)
+
+				<-ctx.Done()
+
+				return HealthCheckFail(ctx.Err())
+			}), HealthCheckOptions{Timeout: time.Hour}),
+		}
+	}, healthCheckProcessor)
+
+	<-started
+
+	done := make(chan struct{})
+
+	go func() {
+		cache.close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("close must cancel the running evaluation")
+	}
+}
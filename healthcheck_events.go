@@ -0,0 +1,145 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"sync"
+
+	"github.com/euskadi31/go-server/metrics"
+)
+
+// HealthCheckEvent is emitted when the status of a health check changes,
+// Previous is empty for the first result of the check.
+type HealthCheckEvent struct {
+	Name     string
+	Previous HealthStatus
+	Current  HealthStatus
+	Result   HealthCheckResult
+}
+
+// HealthCheckSubscriber receives the health check events, it is called synchronously
+// after the evaluation of the checks and must not block.
+type HealthCheckSubscriber func(event HealthCheckEvent)
+
+type healthCheckSubscription struct {
+	id uint64
+	fn HealthCheckSubscriber
+}
+
+// healthCheckObserver records the status of the health checks, exports them as metrics
+// when both the health checks and the metrics are enabled and notifies the subscribers
+// of the status transitions.
+type healthCheckObserver struct {
+	mtx           sync.Mutex
+	statuses      map[string]HealthStatus
+	subscriptions []healthCheckSubscription
+	nextID        uint64
+	healthCheck   bool
+	metricsOpts   *metrics.Options
+	metrics       *healthCheckMetrics
+}
+
+func (o *healthCheckObserver) enableHealthCheck() {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.healthCheck = true
+	o.initMetrics()
+}
+
+func (o *healthCheckObserver) enableMetrics(opts metrics.Options) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.metricsOpts = &opts
+	o.initMetrics()
+}
+
+// initMetrics must be called with the lock held.
+func (o *healthCheckObserver) initMetrics() {
+	if o.healthCheck && o.metricsOpts != nil && o.metrics == nil {
+		o.metrics = newHealthCheckMetrics(*o.metricsOpts)
+	}
+}
+
+func (o *healthCheckObserver) subscribe(fn HealthCheckSubscriber) func() {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.nextID++
+
+	id := o.nextID
+
+	o.subscriptions = append(o.subscriptions, healthCheckSubscription{
+		id: id,
+		fn: fn,
+	})
+
+	return func() {
+		o.mtx.Lock()
+		defer o.mtx.Unlock()
+
+		for i, subscription := range o.subscriptions {
+			if subscription.id == id {
+				o.subscriptions = append(o.subscriptions[:i:i], o.subscriptions[i+1:]...)
+
+				return
+			}
+		}
+	}
+}
+
+// forget the status and the metrics of a removed check.
+func (o *healthCheckObserver) forget(name string) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	delete(o.statuses, name)
+
+	if o.metrics != nil {
+		o.metrics.delete(name)
+	}
+}
+
+func (o *healthCheckObserver) observe(name string, check *healthCheck, result HealthCheckResult) {
+	o.mtx.Lock()
+
+	// The check was removed during the evaluation, forget must not be undone.
+	if check.removed.Load() {
+		o.mtx.Unlock()
+
+		return
+	}
+
+	if o.statuses == nil {
+		o.statuses = make(map[string]HealthStatus)
+	}
+
+	previous := o.statuses[name]
+	o.statuses[name] = result.Status
+
+	if o.metrics != nil {
+		o.metrics.observe(name, result)
+	}
+
+	var subscriptions []healthCheckSubscription
+
+	if previous != result.Status {
+		subscriptions = o.subscriptions
+	}
+
+	o.mtx.Unlock()
+
+	event := HealthCheckEvent{
+		Name:     name,
+		Previous: previous,
+		Current:  result.Status,
+		Result:   result,
+	}
+
+	for _, subscription := range subscriptions {
+		subscription.fn(event)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterSubscribeHealthCheck(t *testing.T) {
+	healthy := true
+
+	router := NewRouter()
+
+	err := router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		if healthy {
+			return HealthCheckPass()
+		}
+
+		return HealthCheckFail(errors.New("connection refused"))
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	var events []HealthCheckEvent
+
+	unsubscribe := router.SubscribeHealthCheck(func(event HealthCheckEvent) {
+		events = append(events, event)
+	})
+
+	router.healthCheck(context.Background(), "")
+	router.healthCheck(context.Background(), "")
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, "redis", events[0].Name)
+	assert.Equal(t, HealthStatus(""), events[0].Previous)
+	assert.Equal(t, HealthStatusPass, events[0].Current)
+
+	healthy = false
+
+	router.healthCheck(context.Background(), "")
+	router.healthCheck(context.Background(), "")
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, HealthStatusPass, events[1].Previous)
+	assert.Equal(t, HealthStatusFail, events[1].Current)
+	assert.Equal(t, "connection refused", events[1].Result.Output)
+
+	unsubscribe()
+
+	healthy = true
+
+	router.healthCheck(context.Background(), "")
+
+	assert.Len(t, events, 2)
+}
+
+func TestHealthCheckObserverUnsubscribe(t *testing.T) {
+	var calls []int
+
+	o := &healthCheckObserver{}
+
+	unsubscribe1 := o.subscribe(func(event HealthCheckEvent) {
+		calls = append(calls, 1)
+	})
+	o.subscribe(func(event HealthCheckEvent) {
+		calls = append(calls, 2)
+	})
+
+	unsubscribe1()
+	unsubscribe1()
+
+	o.observe("redis", newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckPass()
+	}), HealthCheckOptions{}), HealthCheckPass())
+
+	assert.Equal(t, []int{2}, calls)
+}
+
+func TestRouterHealthCheckMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.EnableMetricsWithOptions(metrics.Options{
+		Namespace:  "app",
+		Registerer: registry,
+	})
+	assert.NoError(t, err)
+
+	err = router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckWarn("slow")
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+
+	m := router.healthObserver.metrics
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.status.WithLabelValues("redis", "pass")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.status.WithLabelValues("redis", "warn")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.status.WithLabelValues("redis", "fail")))
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/metrics", nil))
+
+	assert.Contains(t, w.Body.String(), `app_health_check_status{check="redis",status="warn"} 1`)
+	assert.Contains(t, w.Body.String(), `app_health_check_duration_seconds_count{check="redis"} 1`)
+}
+
+func TestRouterHealthCheckMetricsWithOptions(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.EnableMetricsWithOptions(metrics.Options{
+		Namespace:       "app",
+		Subsystem:       "api",
+		DurationBuckets: []float64{0.1, 1},
+		Registerer:      registry,
+	})
+	assert.NoError(t, err)
+
+	err = router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckPass()
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/metrics", nil))
+
+	assert.Contains(t, w.Body.String(), `app_api_health_check_status{check="redis",status="pass"} 1`)
+	assert.Contains(t, w.Body.String(), `app_api_health_check_duration_seconds_bucket{check="redis",le="1"} 1`)
+	assert.NotContains(t, w.Body.String(), `app_api_health_check_duration_seconds_bucket{check="redis",le="0.005"}`)
+
+	count, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "app_api_health_check_status", "app_api_health_check_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count, "nothing is registered in the default registerer")
+}
+
+func TestRouterRemoveHealthCheckDeletesMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.EnableMetricsWithOptions(metrics.Options{
+		Registerer: registry,
+	})
+	assert.NoError(t, err)
+
+	err = router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckPass()
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+
+	assert.Equal(t, 3, testutil.CollectAndCount(router.healthObserver.metrics.status))
+
+	assert.NoError(t, router.RemoveHealthCheck("redis"))
+
+	assert.Equal(t, 0, testutil.CollectAndCount(router.healthObserver.metrics.status))
+	assert.Equal(t, 0, testutil.CollectAndCount(router.healthObserver.metrics.duration))
+	assert.NotContains(t, router.healthObserver.statuses, "redis")
+}
+
+func TestRouterRemoveHealthCheckDuringEvaluation(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.EnableMetricsWithOptions(metrics.Options{
+		Registerer: prometheus.NewRegistry(),
+	})
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	err = router.AddHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		close(started)
+		<-release
+
+		return HealthCheckPass()
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+	}()
+
+	<-started
+
+	assert.NoError(t, router.RemoveHealthCheck("redis"))
+
+	close(release)
+	<-done
+
+	assert.Equal(t, 0, testutil.CollectAndCount(router.healthObserver.metrics.status))
+	assert.Equal(t, 0, testutil.CollectAndCount(router.healthObserver.metrics.duration))
+	assert.NotContains(t, router.healthObserver.statuses, "redis")
+}
+
+func TestRouterHealthCheckMetricsRequireBothFeatures(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	assert.Nil(t, router.healthObserver.metrics)
+
+	router = NewRouter()
+
+	err := router.EnableMetricsWithOptions(metrics.Options{
+		Registerer: prometheus.NewRegistry(),
+	})
+	assert.NoError(t, err)
+
+	assert.Nil(t, router.healthObserver.metrics)
+}
+
+func TestNewHealthCheckMetricsReusesRegisteredMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	m1 := newHealthCheckMetrics(metrics.Options{Registerer: registry})
+	m2 := newHealthCheckMetrics(metrics.Options{Registerer: registry})
+
+	assert.Same(t, m1.status, m2.status)
+	assert.Same(t, m1.duration, m2.duration)
+}
+
+func TestRouterHealthCheckIgnoresRequestCancellation(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.EnableMetricsWithOptions(metrics.Options{
+		Registerer: registry,
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err = router.AddHealthChecker("redis", HealthCheckerFunc(func(checkCtx context.Context) HealthCheckResult {
+		// The probe disconnects while the check is running.
+		cancel()
+
+		<-checkCtx.Done()
+
+		return HealthCheckFail(checkCtx.Err())
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	var events []HealthCheckEvent
+
+	router.SubscribeHealthCheck(func(event HealthCheckEvent) {
+		events = append(events, event)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/health", nil).WithContext(ctx))
+
+	assert.Empty(t, events)
+
+	m := router.healthObserver.metrics
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.status.WithLabelValues("redis", "fail")))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.duration))
+}
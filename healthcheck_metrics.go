@@ -0,0 +1,98 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"errors"
+
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+var healthStatuses = [...]HealthStatus{HealthStatusPass, HealthStatusWarn, HealthStatusFail}
+
+// healthCheckMetrics exports the results of the health checks.
+type healthCheckMetrics struct {
+	status   *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+}
+
+// newHealthCheckMetrics registers the health check metrics in opts.Registerer,
+// the metrics already registered by another router are reused. The duration histogram
+// uses opts.DurationBuckets, prometheus.DefBuckets when nil.
+func newHealthCheckMetrics(opts metrics.Options) *healthCheckMetrics {
+	registerer := opts.Registerer
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	buckets := opts.DurationBuckets
+	if buckets == nil {
+		buckets = prometheus.DefBuckets
+	}
+
+	m := &healthCheckMetrics{
+		status: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   opts.Subsystem,
+			Name:        "health_check_status",
+			Help:        "The current status of the health check, 1 for the current status and 0 for the others.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"check", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   opts.Subsystem,
+			Name:        "health_check_duration_seconds",
+			Help:        "The duration of the health checks.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     buckets,
+		}, []string{"check"}),
+	}
+
+	if c, ok := registerOrExisting(registerer, m.status).(*prometheus.GaugeVec); ok {
+		m.status = c
+	}
+
+	if c, ok := registerOrExisting(registerer, m.duration).(*prometheus.HistogramVec); ok {
+		m.duration = c
+	}
+
+	return m
+}
+
+func registerOrExisting(registerer prometheus.Registerer, c prometheus.Collector) prometheus.Collector {
+	if err := registerer.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			return are.ExistingCollector
+		}
+
+		log.Error().Err(err).Msg("prometheus register")
+	}
+
+	return c
+}
+
+func (m *healthCheckMetrics) observe(name string, result HealthCheckResult) {
+	for _, status := range healthStatuses {
+		value := 0.0
+		if status == result.Status {
+			value = 1
+		}
+
+		m.status.WithLabelValues(name, string(status)).Set(value)
+	}
+
+	m.duration.WithLabelValues(name).Observe(result.Duration.Seconds())
+}
+
+func (m *healthCheckMetrics) delete(name string) {
+	for _, status := range healthStatuses {
+		m.status.DeleteLabelValues(name, string(status))
+	}
+
+	m.duration.DeleteLabelValues(name)
+}
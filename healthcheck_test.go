@@ -5,52 +5,214 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHealthcheckProcessor(t *testing.T) {
-	healthchecks := make(map[string]HealthCheckHandler)
+	healthchecks := make(map[string]*healthCheck)
 
 	mysql := &MockHealthCheckHandler{}
 	mysql.On("Check").Return(true)
 
-	healthchecks["mysql"] = mysql
+	healthchecks["mysql"] = newHealthCheck(legacyHealthCheck(mysql), HealthCheckOptions{})
 
-	response := healthCheckProcessor(healthchecks)
+	response := healthCheckProcessor(context.Background(), healthchecks)
 
-	if response.Status != true {
-		t.Error("response.Status is not true")
+	if response.Status != HealthStatusPass {
+		t.Error("response.Status is not pass")
 	}
 
-	if response.Services["mysql"] != true {
-		t.Error(`response.Services["mysql"] is not true`)
+	if response.Checks["mysql"][0].Status != HealthStatusPass {
+		t.Error(`response.Checks["mysql"] is not pass`)
 	}
 }
 
 func TestHealthcheckProcessorWithFailedCheck(t *testing.T) {
-	healthchecks := make(map[string]HealthCheckHandler)
+	healthchecks := make(map[string]*healthCheck)
 
 	mysql := &MockHealthCheckHandler{}
 	mysql.On("Check").Return(true)
 
-	healthchecks["mysql"] = mysql
+	healthchecks["mysql"] = newHealthCheck(legacyHealthCheck(mysql), HealthCheckOptions{})
 
 	redis := &MockHealthCheckHandler{}
 	redis.On("Check").Return(false)
 
-	healthchecks["redis"] = redis
+	healthchecks["redis"] = newHealthCheck(legacyHealthCheck(redis), HealthCheckOptions{})
+
+	response := healthCheckProcessor(context.Background(), healthchecks)
 
-	response := healthCheckProcessor(healthchecks)
+	if response.Status != HealthStatusFail {
+		t.Error("response.Status is not fail")
+	}
 
-	if response.Status != false {
-		t.Error("response.Status is not false")
+	if response.Checks["mysql"][0].Status != HealthStatusPass {
+		t.Error(`response.Checks["mysql"] is not pass`)
 	}
 
-	if response.Services["mysql"] != true {
-		t.Error(`response.Services["mysql"] is not true`)
+	if response.Checks["redis"][0].Status != HealthStatusFail {
+		t.Error(`response.Checks["redis"] is not fail`)
 	}
+}
+
+func TestHealthcheckProcessorWithWarning(t *testing.T) {
+	cache := &MockHealthChecker{}
+	cache.On("CheckHealth", mock.Anything).Return(HealthCheckResult{
+		Status:        HealthStatusWarn,
+		Output:        "High latency",
+		ObservedValue: 250,
+		ObservedUnit:  "ms",
+	})
+
+	response := healthCheckProcessor(context.Background(), map[string]*healthCheck{
+		"cache": newHealthCheck(cache, HealthCheckOptions{}),
+		"db":    newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult { return HealthCheckPass() }), HealthCheckOptions{}),
+	})
+
+	assert.Equal(t, HealthStatusWarn, response.Status)
+	assert.Equal(t, http.StatusOK, response.StatusCode())
+
+	result := response.Checks["cache"][0]
+
+	assert.Equal(t, HealthStatusWarn, result.Status)
+	assert.Equal(t, "High latency", result.Output)
+	assert.Equal(t, 250, result.ObservedValue)
+	assert.False(t, result.Time.IsZero())
+}
+
+func TestHealthcheckProcessorWithNonCriticalFailure(t *testing.T) {
+	response := healthCheckProcessor(context.Background(), map[string]*healthCheck{
+		"cache": newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+			return HealthCheckFail(errors.New("connection refused"))
+		}), HealthCheckOptions{NonCritical: true}),
+		"db": newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+			return HealthCheckPass()
+		}), HealthCheckOptions{}),
+	})
+
+	assert.Equal(t, HealthStatusWarn, response.Status)
+	assert.Equal(t, http.StatusOK, response.StatusCode())
+	assert.Equal(t, HealthStatusFail, response.Checks["cache"][0].Status)
+}
+
+func TestHealthcheckProcessorRunsChecksConcurrently(t *testing.T) {
+	healthchecks := make(map[string]*healthCheck)
 
-	if response.Services["redis"] != false {
-		t.Error(`response.Services["redis"] is not false`)
+	for _, name := range []string{"mysql", "redis", "kafka", "s3"} {
+		healthchecks[name] = newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+			time.Sleep(100 * time.Millisecond)
+
+			return HealthCheckPass()
+		}), HealthCheckOptions{})
 	}
+
+	start := time.Now()
+
+	response := healthCheckProcessor(context.Background(), healthchecks)
+
+	assert.Less(t, time.Since(start), 300*time.Millisecond, "the checks must not be serialized")
+	assert.Equal(t, HealthStatusPass, response.Status)
+	assert.Len(t, response.Checks, 4)
+}
+
+func TestHealthCheckRunWithTimeout(t *testing.T) {
+	done := make(chan struct{})
+
+	check := newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		defer close(done)
+
+		<-ctx.Done()
+
+		// The result of a check returning after its deadline is ignored.
+		return HealthCheckPass()
+	}), HealthCheckOptions{
+		Timeout: 10 * time.Millisecond,
+	})
+
+	result, ok := check.run(context.Background())
+	assert.True(t, ok)
+
+	assert.Equal(t, HealthStatusFail, result.Status)
+	assert.Equal(t, "health check timed out after 10ms", result.Output)
+	assert.GreaterOrEqual(t, result.Duration, 10*time.Millisecond)
+
+	<-done
+}
+
+func TestHealthCheckRunWithPanic(t *testing.T) {
+	check := newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		panic("boom")
+	}), HealthCheckOptions{})
+
+	result, ok := check.run(context.Background())
+	assert.True(t, ok)
+
+	assert.Equal(t, HealthStatusFail, result.Status)
+	assert.Equal(t, "panic: boom", result.Output)
+}
+
+func TestHealthCheckRunWithoutStatus(t *testing.T) {
+	check := newHealthCheck(HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckResult{}
+	}), HealthCheckOptions{})
+
+	result, ok := check.run(context.Background())
+	assert.True(t, ok)
+	assert.Equal(t, HealthStatusPass, result.Status)
+	assert.Equal(t, DefaultHealthCheckTimeout, check.timeout)
+}
+
+func TestHealthCheckResultMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(HealthCheckResult{
+		Status:        HealthStatusFail,
+		Output:        "connection refused",
+		ObservedValue: 12.5,
+		ObservedUnit:  "ms",
+		Duration:      1500 * time.Microsecond,
+		Time:          time.Date(2026, time.October, 10, 13, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"status": "fail",
+		"output": "connection refused",
+		"observedValue": 12.5,
+		"observedUnit": "ms",
+		"duration": "1.5ms",
+		"time": "2026-10-10T13:00:00Z"
+	}`, string(b))
+
+	b, err = json.Marshal(HealthCheckPass())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":"pass"}`, string(b))
+}
+
+func TestHealthCheckResults(t *testing.T) {
+	assert.Equal(t, HealthStatusPass, HealthCheckPass().Status)
+	assert.Equal(t, HealthCheckResult{Status: HealthStatusWarn, Output: "slow"}, HealthCheckWarn("slow"))
+	assert.Equal(t, HealthCheckResult{Status: HealthStatusFail, Output: "down"}, HealthCheckFail(errors.New("down")))
+}
+
+func TestWriteHealthCheckResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	writeHealthCheckResponse(w, HealthCheckResponse{
+		Status: HealthStatusFail,
+		Checks: map[string][]HealthCheckResult{
+			"redis": {HealthCheckFail(errors.New("down"))},
+		},
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, HealthCheckMediaType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":"fail","checks":{"redis":[{"status":"fail","output":"down"}]}}`, w.Body.String())
 }
@@ -0,0 +1,36 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package countio counts the bytes of the request bodies of unknown length
+// used as span tags and metrics.
+package countio
+
+import (
+	"io"
+)
+
+// Reader counts the bytes read from a body.
+type Reader struct {
+	io.ReadCloser
+	n int64
+}
+
+// NewReader wraps body.
+func NewReader(body io.ReadCloser) *Reader {
+	return &Reader{
+		ReadCloser: body,
+	}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+
+	return n, err // nolint: wrapcheck
+}
+
+// Count returns the number of bytes read.
+func (r *Reader) Count() int64 {
+	return r.n
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package reqinfo shares the request information resolved by the inner middlewares
+// (ex: the authenticated principal, the request ID) with the outer middlewares like the access log.
+package reqinfo
+
+import (
+	"context"
+	"sync"
+)
+
+type key int
+
+const (
+	contextKey key = iota
+)
+
+// Info is the request information filled by the inner middlewares.
+type Info struct {
+	mtx       sync.RWMutex
+	principal string
+	requestID string
+}
+
+// ToContext adds an empty Info to the context.
+func ToContext(ctx context.Context) (context.Context, *Info) {
+	info := &Info{}
+
+	return context.WithValue(ctx, contextKey, info), info
+}
+
+// FromContext returns the Info of the context.
+func FromContext(ctx context.Context) (*Info, bool) {
+	info, ok := ctx.Value(contextKey).(*Info)
+
+	return info, ok
+}
+
+// SetPrincipal sets the subject of the authenticated principal, it is a noop
+// when the context has no Info.
+func SetPrincipal(ctx context.Context, subject string) {
+	if info, ok := FromContext(ctx); ok {
+		info.mtx.Lock()
+		info.principal = subject
+		info.mtx.Unlock()
+	}
+}
+
+// Principal returns the subject of the authenticated principal.
+func (i *Info) Principal() string {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+
+	return i.principal
+}
+
+// SetRequestID sets the request ID, it is a noop when the context has no Info.
+func SetRequestID(ctx context.Context, id string) {
+	if info, ok := FromContext(ctx); ok {
+		info.mtx.Lock()
+		info.requestID = id
+		info.mtx.Unlock()
+	}
+}
+
+// RequestID returns the request ID.
+func (i *Info) RequestID() string {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+
+	return i.requestID
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package reqinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPrincipal(t *testing.T) {
+	ctx, info := ToContext(context.Background())
+
+	assert.Empty(t, info.Principal())
+
+	SetPrincipal(ctx, "user-1")
+
+	assert.Equal(t, "user-1", info.Principal())
+}
+
+func TestSetPrincipalWithoutInfo(t *testing.T) {
+	ctx := context.Background()
+
+	SetPrincipal(ctx, "user-1")
+
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+}
+
+func TestSetRequestID(t *testing.T) {
+	ctx, info := ToContext(context.Background())
+
+	assert.Empty(t, info.RequestID())
+
+	SetRequestID(ctx, "abc-123")
+
+	assert.Equal(t, "abc-123", info.RequestID())
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package route extracts the route templates of gorilla/mux used as
+// span names, metric labels and log fields.
+package route
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Template returns the path template of the mux route matched by the request,
+// without the regexp patterns of the variables.
+func Template(req *http.Request) (string, bool) {
+	route := mux.CurrentRoute(req)
+	if route == nil {
+		return "", false
+	}
+
+	tpl, err := route.GetPathTemplate()
+	if err != nil {
+		return "", false
+	}
+
+	return MassageTemplate(tpl), true
+}
+
+// MassageTemplate removes the regexp patterns from template variables.
+func MassageTemplate(tpl string) string {
+	braces := braceIndices(tpl)
+
+	if len(braces) == 0 {
+		return tpl
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(tpl)))
+
+	for i := 0; i < len(tpl); {
+		var j int
+
+		if i < braces[0] {
+			j = braces[0]
+
+			buf.WriteString(tpl[i:j])
+		} else {
+			j = braces[1]
+			field := tpl[i:j]
+
+			if colon := strings.IndexRune(field, ':'); colon >= 0 {
+				buf.WriteString(field[:colon])
+				buf.WriteRune('}')
+			} else {
+				buf.WriteString(field)
+			}
+
+			braces = braces[2:]
+
+			if len(braces) == 0 {
+				buf.WriteString(tpl[j:])
+
+				break
+			}
+		}
+
+		i = j
+	}
+
+	return buf.String()
+}
+
+// Copied/adapted from gorilla/mux. The original version checks
+// that the braces are matched up correctly; we assume they are,
+// as otherwise the path wouldn't have been registered correctly.
+func braceIndices(s string) []int {
+	var level, idx int
+
+	var idxs []int
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			if level++; level == 1 {
+				idx = i
+			}
+		case '}':
+			if level--; level == 0 {
+				idxs = append(idxs, idx, i+1)
+			}
+		}
+	}
+
+	return idxs
+}
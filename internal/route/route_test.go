@@ -0,0 +1,50 @@
+// Copyright 2018 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMassageTemplate(t *testing.T) {
+	out := MassageTemplate("/articles/{category}/{id:[0-9]+}")
+
+	assert.Equal(t, "/articles/{category}/{id}", out)
+
+	out = MassageTemplate("/articles")
+
+	assert.Equal(t, "/articles", out)
+}
+
+func TestTemplateWithoutRoute(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	_, ok := Template(req)
+	assert.False(t, ok)
+}
+
+func TestTemplate(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo/123", nil)
+
+	called := false
+
+	r := mux.NewRouter()
+	r.HandleFunc("/foo/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+
+		tpl, ok := Template(r)
+		assert.True(t, ok)
+		assert.Equal(t, "/foo/{id}", tpl)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, called)
+}
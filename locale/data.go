@@ -0,0 +1,189 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package locale
+
+// Date and relative time data extracted from CLDR for the most common languages,
+// unknown languages fallback to english.
+
+type dateData struct {
+	patterns    [4]string
+	months      [12]string
+	shortMonths [12]string
+	weekdays    [7]string
+}
+
+type relativeUnit int
+
+const (
+	relativeSecond relativeUnit = iota
+	relativeMinute
+	relativeHour
+	relativeDay
+	relativeMonth
+	relativeYear
+)
+
+type relativeData struct {
+	now    string
+	future string
+	past   string
+	// units contains the singular and plural label of each unit.
+	units [6][2]string
+}
+
+var (
+	englishMonths = [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	}
+
+	englishShortMonths = [12]string{
+		"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+	}
+
+	englishWeekdays = [7]string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	}
+)
+
+var dates = map[string]*dateData{
+	"en": {
+		patterns:    [4]string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+		months:      englishMonths,
+		shortMonths: englishShortMonths,
+		weekdays:    englishWeekdays,
+	},
+	"en-001": {
+		patterns:    [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE, d MMMM y"},
+		months:      englishMonths,
+		shortMonths: englishShortMonths,
+		weekdays:    englishWeekdays,
+	},
+	"fr": {
+		patterns: [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		months: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		shortMonths: [12]string{
+			"janv.", "févr.", "mars", "avr.", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc.",
+		},
+		weekdays: [7]string{
+			"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi",
+		},
+	},
+	"es": {
+		patterns: [4]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		months: [12]string{
+			"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		},
+		shortMonths: [12]string{
+			"ene", "feb", "mar", "abr", "may", "jun",
+			"jul", "ago", "sept", "oct", "nov", "dic",
+		},
+		weekdays: [7]string{
+			"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado",
+		},
+	},
+	"it": {
+		patterns: [4]string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		months: [12]string{
+			"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		},
+		shortMonths: [12]string{
+			"gen", "feb", "mar", "apr", "mag", "giu",
+			"lug", "ago", "set", "ott", "nov", "dic",
+		},
+		weekdays: [7]string{
+			"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato",
+		},
+	},
+	"de": {
+		patterns: [4]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		months: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		shortMonths: [12]string{
+			"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni",
+			"Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez.",
+		},
+		weekdays: [7]string{
+			"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag",
+		},
+	},
+}
+
+var relatives = map[string]*relativeData{
+	"en": {
+		now:    "now",
+		future: "in {0}",
+		past:   "{0} ago",
+		units: [6][2]string{
+			{"second", "seconds"},
+			{"minute", "minutes"},
+			{"hour", "hours"},
+			{"day", "days"},
+			{"month", "months"},
+			{"year", "years"},
+		},
+	},
+	"fr": {
+		now:    "maintenant",
+		future: "dans {0}",
+		past:   "il y a {0}",
+		units: [6][2]string{
+			{"seconde", "secondes"},
+			{"minute", "minutes"},
+			{"heure", "heures"},
+			{"jour", "jours"},
+			{"mois", "mois"},
+			{"an", "ans"},
+		},
+	},
+	"es": {
+		now:    "ahora",
+		future: "dentro de {0}",
+		past:   "hace {0}",
+		units: [6][2]string{
+			{"segundo", "segundos"},
+			{"minuto", "minutos"},
+			{"hora", "horas"},
+			{"día", "días"},
+			{"mes", "meses"},
+			{"año", "años"},
+		},
+	},
+	"it": {
+		now:    "ora",
+		future: "tra {0}",
+		past:   "{0} fa",
+		units: [6][2]string{
+			{"secondo", "secondi"},
+			{"minuto", "minuti"},
+			{"ora", "ore"},
+			{"giorno", "giorni"},
+			{"mese", "mesi"},
+			{"anno", "anni"},
+		},
+	},
+	"de": {
+		now:    "jetzt",
+		future: "in {0}",
+		past:   "vor {0}",
+		units: [6][2]string{
+			{"Sekunde", "Sekunden"},
+			{"Minute", "Minuten"},
+			{"Stunde", "Stunden"},
+			{"Tag", "Tagen"},
+			{"Monat", "Monaten"},
+			{"Jahr", "Jahren"},
+		},
+	},
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package locale
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// DateStyle type.
+type DateStyle int
+
+// DateStyle values, following the CLDR date format lengths.
+const (
+	DateShort DateStyle = iota
+	DateMedium
+	DateLong
+	DateFull
+)
+
+// Printer returns a message.Printer for the locale.
+func (l Locale) Printer() *message.Printer {
+	return message.NewPrinter(l.Tag)
+}
+
+// FormatNumber formats a number with the grouping and decimal separators of the locale.
+func (l Locale) FormatNumber(value interface{}) string {
+	return l.Printer().Sprint(number.Decimal(value))
+}
+
+// FormatPercent formats a ratio as a percentage (0.25 => 25%).
+func (l Locale) FormatPercent(value interface{}) string {
+	return l.Printer().Sprint(number.Percent(value))
+}
+
+// FormatCurrency formats an amount in the currency of the locale.
+func (l Locale) FormatCurrency(amount interface{}) string {
+	return l.FormatCurrencyUnit(l.Currency(), amount)
+}
+
+// FormatCurrencyUnit formats an amount in the given currency.
+func (l Locale) FormatCurrencyUnit(unit currency.Unit, amount interface{}) string {
+	return l.Printer().Sprint(currency.Symbol(unit.Amount(amount)))
+}
+
+// dateCalendar is a calendar supported by FormatDate, they only differ from the gregorian
+// calendar by the numbering of the years.
+type dateCalendar struct {
+	yearOffset int
+	era        string
+}
+
+// dateCalendars supported by FormatDate, indexed by their "ca" extension value.
+var dateCalendars = map[string]dateCalendar{
+	DefaultCalendar: {},
+	"gregory":       {},
+	"iso8601":       {},
+	"buddhist":      {yearOffset: 543, era: "BE"},
+	"roc":           {yearOffset: -1911, era: "Minguo"},
+}
+
+// DateCalendar returns the calendar used by FormatDate: the calendar of the locale
+// when it is supported (gregorian, buddhist or roc), DefaultCalendar otherwise.
+func (l Locale) DateCalendar() string {
+	calendar := l.Calendar()
+	if _, ok := dateCalendars[calendar]; !ok {
+		return DefaultCalendar
+	}
+
+	return calendar
+}
+
+// FormatDate formats the date part of t with the given style in the calendar of the locale,
+// the years of the buddhist and roc calendars are followed by their era. The other calendars
+// (ex: japanese, islamic) are not supported and fall back to the gregorian calendar,
+// see DateCalendar.
+func (l Locale) FormatDate(t time.Time, style DateStyle) string {
+	data := l.dateData()
+
+	pattern := data.patterns[DateMedium]
+	if style >= DateShort && style <= DateFull {
+		pattern = data.patterns[style]
+	}
+
+	calendar := dateCalendars[l.DateCalendar()]
+
+	text := formatDatePattern(t, pattern, data, calendar.yearOffset)
+	if calendar.era != "" {
+		text += " " + calendar.era
+	}
+
+	return text
+}
+
+// FormatRelativeTime formats a duration relative to now ("in 3 days", "2 hours ago").
+// Negative durations are in the past.
+func (l Locale) FormatRelativeTime(d time.Duration) string {
+	data := l.relativeData()
+
+	value, unit := relativeValue(d)
+	if value == 0 {
+		return data.now
+	}
+
+	count := value
+	if count < 0 {
+		count = -count
+	}
+
+	label := data.units[unit][1]
+	if plural.Cardinal.MatchPlural(l.Tag, count, 0, 0, 0, 0) == plural.One {
+		label = data.units[unit][0]
+	}
+
+	text := l.FormatNumber(count) + " " + label
+
+	if value < 0 {
+		return strings.Replace(data.past, "{0}", text, 1)
+	}
+
+	return strings.Replace(data.future, "{0}", text, 1)
+}
+
+func (l Locale) dateData() *dateData {
+	data, ok := dates[l.Language]
+	if !ok {
+		data = dates[DefaultLanguage]
+	}
+
+	// Only the United States use the month first order for english.
+	if l.Language == "en" && l.Region != "US" && l.Region != "" && l.Region != "ZZ" {
+		data = dates["en-001"]
+	}
+
+	return data
+}
+
+func (l Locale) relativeData() *relativeData {
+	if data, ok := relatives[l.Language]; ok {
+		return data
+	}
+
+	return relatives[DefaultLanguage]
+}
+
+func relativeValue(d time.Duration) (int, relativeUnit) {
+	abs := math.Abs(d.Seconds())
+
+	switch {
+	case abs < 60:
+		return round(d.Seconds()), relativeSecond
+	case abs < 3600:
+		return round(d.Minutes()), relativeMinute
+	case abs < 86400:
+		return round(d.Hours()), relativeHour
+	case abs < 30*86400:
+		return round(d.Hours() / 24), relativeDay
+	case abs < 365*86400:
+		return round(d.Hours() / (24 * 30)), relativeMonth
+	default:
+		return round(d.Hours() / (24 * 365)), relativeYear
+	}
+}
+
+func round(f float64) int {
+	return int(math.Round(f))
+}
+
+// formatDatePattern supports the subset of the CLDR date pattern syntax used by the date data:
+// d, dd, M, MM, MMM, MMMM, y, yy, EEEE and quoted literals.
+func formatDatePattern(t time.Time, pattern string, data *dateData, yearOffset int) string {
+	year := t.Year() + yearOffset
+
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+
+		if c == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				b.WriteString(pattern[i+1:])
+
+				break
+			}
+
+			b.WriteString(pattern[i+1 : i+1+end])
+
+			i += end + 2
+
+			continue
+		}
+
+		j := i
+		for j < len(pattern) && pattern[j] == c {
+			j++
+		}
+
+		n := j - i
+
+		switch c {
+		case 'd':
+			b.WriteString(pad(t.Day(), n))
+		case 'M':
+			switch {
+			case n >= 4:
+				b.WriteString(data.months[t.Month()-1])
+			case n == 3:
+				b.WriteString(data.shortMonths[t.Month()-1])
+			default:
+				b.WriteString(pad(int(t.Month()), n))
+			}
+		case 'y':
+			if n == 2 {
+				b.WriteString(pad(year%100, 2))
+			} else {
+				b.WriteString(strconv.Itoa(year))
+			}
+		case 'E':
+			b.WriteString(data.weekdays[t.Weekday()])
+		default:
+			b.WriteString(pattern[i:j])
+		}
+
+		i = j
+	}
+
+	return b.String()
+}
+
+func pad(value int, width int) string {
+	s := strconv.Itoa(value)
+
+	for len(s) < width {
+		s = "0" + s
+	}
+
+	return s
+}
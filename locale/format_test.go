@@ -0,0 +1,108 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package locale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/currency"
+)
+
+func mustParse(t *testing.T, s string) Locale {
+	t.Helper()
+
+	l, err := Parse(s)
+	assert.NoError(t, err)
+
+	return l
+}
+
+func TestFormatNumber(t *testing.T) {
+	assert.Equal(t, "1,234,567.5", mustParse(t, "en-US").FormatNumber(1234567.5))
+	assert.Equal(t, "1.234.567,5", mustParse(t, "de-DE").FormatNumber(1234567.5))
+	assert.Equal(t, "25%", mustParse(t, "en-US").FormatPercent(0.25))
+}
+
+func TestFormatCurrency(t *testing.T) {
+	assert.Equal(t, "$ 1,234.50", mustParse(t, "en-US").FormatCurrency(1234.5))
+	assert.Equal(t, "€ 1.234,50", mustParse(t, "de-DE").FormatCurrency(1234.5))
+	assert.Equal(t, "$US 12,00", mustParse(t, "fr-FR-u-cu-usd").FormatCurrency(12))
+	assert.Equal(t, "£ 3.00", mustParse(t, "en-US").FormatCurrencyUnit(currency.GBP, 3))
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2019, time.March, 7, 10, 0, 0, 0, time.UTC)
+
+	for _, item := range []struct {
+		locale   string
+		style    DateStyle
+		expected string
+	}{
+		{"en-US", DateShort, "3/7/19"},
+		{"en-US", DateMedium, "Mar 7, 2019"},
+		{"en-US", DateLong, "March 7, 2019"},
+		{"en-US", DateFull, "Thursday, March 7, 2019"},
+		{"en-GB", DateShort, "07/03/2019"},
+		{"en-GB", DateLong, "7 March 2019"},
+		{"fr-FR", DateShort, "07/03/2019"},
+		{"fr-FR", DateFull, "jeudi 7 mars 2019"},
+		{"es-ES", DateLong, "7 de marzo de 2019"},
+		{"it-IT", DateMedium, "7 mar 2019"},
+		{"de-DE", DateFull, "Donnerstag, 7. März 2019"},
+		{"ja-JP", DateMedium, "Mar 7, 2019"},
+		{"en-US", DateStyle(42), "Mar 7, 2019"},
+	} {
+		assert.Equal(t, item.expected, mustParse(t, item.locale).FormatDate(date, item.style), item.locale)
+	}
+}
+
+func TestFormatDateWithCalendar(t *testing.T) {
+	date := time.Date(2019, time.March, 7, 10, 0, 0, 0, time.UTC)
+
+	for _, item := range []struct {
+		locale   string
+		style    DateStyle
+		calendar string
+		expected string
+	}{
+		{"en-US-u-ca-gregory", DateMedium, "gregory", "Mar 7, 2019"},
+		{"en-US-u-ca-buddhist", DateMedium, "buddhist", "Mar 7, 2562 BE"},
+		{"en-US-u-ca-buddhist", DateShort, "buddhist", "3/7/62 BE"},
+		{"en-US-u-ca-roc", DateLong, "roc", "March 7, 108 Minguo"},
+		{"en-US-u-ca-japanese", DateMedium, DefaultCalendar, "Mar 7, 2019"},
+		{"en-US-u-ca-islamic", DateMedium, DefaultCalendar, "Mar 7, 2019"},
+	} {
+		l := mustParse(t, item.locale)
+
+		assert.Equal(t, item.calendar, l.DateCalendar(), item.locale)
+		assert.Equal(t, item.expected, l.FormatDate(date, item.style), item.locale)
+	}
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	for _, item := range []struct {
+		locale   string
+		duration time.Duration
+		expected string
+	}{
+		{"en-US", 0, "now"},
+		{"en-US", 30 * time.Second, "in 30 seconds"},
+		{"en-US", -time.Minute, "1 minute ago"},
+		{"en-US", 3 * time.Hour, "in 3 hours"},
+		{"en-US", -72 * time.Hour, "3 days ago"},
+		{"en-US", 60 * 24 * time.Hour, "in 2 months"},
+		{"en-US", -2 * 365 * 24 * time.Hour, "2 years ago"},
+		{"fr-FR", -72 * time.Hour, "il y a 3 jours"},
+		{"fr-FR", 24 * time.Hour, "dans 1 jour"},
+		{"es-ES", -time.Hour, "hace 1 hora"},
+		{"it-IT", -2 * time.Hour, "2 ore fa"},
+		{"de-DE", 48 * time.Hour, "in 2 Tagen"},
+		{"ja-JP", 48 * time.Hour, "in 2 days"},
+	} {
+		assert.Equal(t, item.expected, mustParse(t, item.locale).FormatRelativeTime(item.duration), item.locale)
+	}
+}
@@ -16,6 +16,8 @@ import (
 var DefaultLanguage = "en"
 
 // DefaultRegion var.
+//
+// Deprecated: the region is inferred from the negotiated language tag.
 var DefaultRegion = "US"
 
 // DefaultSupported language.
@@ -49,30 +51,48 @@ func HandlerWithConfig(languages []string) func(next http.Handler) http.Handler
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
-			locale := Locale{}
 
 			tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
 			if err != nil {
 				log.Error().Err(err).Msg("language.ParseAcceptLanguage failed")
 
-				locale.Language = DefaultLanguage
-				locale.Region = DefaultRegion
-			} else {
-				tag, _, _ := matcher.Match(tags...)
-				language, _ := tag.Base()
-				region, _ := tag.Region()
-
-				locale.Language = language.String()
-				locale.Region = region.String()
+				tags = nil
 			}
 
-			ctx = ToContext(ctx, locale)
+			ctx = ToContext(ctx, New(negotiate(matcher, tags)))
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// negotiate returns the desired tag matching the supported language, keeping its
+// script, region, variants and extensions, or the matched supported tag otherwise.
+func negotiate(matcher language.Matcher, desired []language.Tag) language.Tag {
+	tag, _, confidence := matcher.Match(desired...)
+	if confidence == language.No {
+		return tag
+	}
+
+	base, _ := tag.Base()
+
+	for _, d := range desired {
+		if b, _ := d.Base(); b != base {
+			continue
+		}
+
+		if script, c := tag.Script(); c == language.Exact {
+			if t, err := language.Compose(d, script); err == nil {
+				return t
+			}
+		}
+
+		return d
+	}
+
+	return tag
+}
+
 // ToContext add Locale to Context.
 func ToContext(ctx context.Context, locale Locale) context.Context {
 	return context.WithValue(ctx, contextKey, locale)
@@ -85,8 +105,5 @@ func FromContext(ctx context.Context) Locale {
 		return value
 	}
 
-	return Locale{
-		Language: DefaultLanguage,
-		Region:   DefaultRegion,
-	}
+	return New(language.Make(DefaultLanguage))
 }
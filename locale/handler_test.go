@@ -163,3 +163,65 @@ func BenchmarkHandler(b *testing.B) {
 		middleware.ServeHTTP(w, req)
 	}
 }
+
+func TestLocaleHandlerPreservesTag(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	middleware := alice.New(HandlerWithConfig([]string{"en", "fr", "sr-Latn"})).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := FromContext(r.Context())
+
+		assert.Equal(t, "fr", locale.Language)
+		assert.Equal(t, "CH", locale.Region)
+		assert.Equal(t, "usd", locale.Extension("cu"))
+		assert.Equal(t, "fr-CH-u-cu-usd", locale.String())
+	})
+
+	req.Header.Set("Accept-Language", "fr-CH-u-cu-usd,en;q=0.8")
+
+	middleware.ServeHTTP(w, req)
+}
+
+func TestLocaleHandlerPreservesVariantsAndScript(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	middleware := alice.New(HandlerWithConfig([]string{"en", "fr", "sr-Latn"})).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := FromContext(r.Context())
+
+		assert.Equal(t, "sr", locale.Language)
+		assert.Equal(t, "Latn", locale.Script)
+		assert.Equal(t, "RS", locale.Region)
+	})
+
+	req.Header.Set("Accept-Language", "sr-RS")
+
+	middleware.ServeHTTP(w, req)
+
+	req.Header.Set("Accept-Language", "en-GB-oxendict")
+
+	middleware = alice.New(HandlerWithConfig([]string{"en", "fr"})).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := FromContext(r.Context())
+
+		assert.Equal(t, "GB", locale.Region)
+		assert.Equal(t, []string{"oxendict"}, locale.Variants())
+	})
+
+	middleware.ServeHTTP(w, req)
+}
+
+func TestLocaleHandlerWithBadAcceptLanguageUsesFallback(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	middleware := alice.New(HandlerWithConfig([]string{"fr", "en"})).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := FromContext(r.Context())
+
+		assert.Equal(t, "fr", locale.Language)
+		assert.Equal(t, "FR", locale.Region)
+	})
+
+	req.Header.Set("Accept-Language", "bad!")
+
+	middleware.ServeHTTP(w, req)
+}
@@ -4,12 +4,110 @@
 
 package locale
 
+import (
+	"fmt"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+// DefaultCalendar used when the locale does not carry a "ca" extension.
+const DefaultCalendar = "gregorian"
+
 // Locale struct.
 type Locale struct {
 	Language string
+	Script   string
 	Region   string
+	Tag      language.Tag
+}
+
+// New Locale from a BCP 47 language tag.
+func New(tag language.Tag) Locale {
+	base, _ := tag.Base()
+	script, _ := tag.Script()
+
+	return Locale{
+		Language: base.String(),
+		Script:   script.String(),
+		Region:   regionOf(tag),
+		Tag:      tag,
+	}
+}
+
+// Parse a BCP 47 language tag and returns the Locale.
+func Parse(s string) (Locale, error) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return Locale{}, fmt.Errorf("failed to parse locale: %w", err)
+	}
+
+	return New(tag), nil
+}
+
+// regionOf returns the region of tag, honoring the "rg" region override extension.
+func regionOf(tag language.Tag) string {
+	if rg := tag.TypeForKey("rg"); len(rg) >= 2 {
+		if region, err := language.ParseRegion(rg[:2]); err == nil {
+			return region.String()
+		}
+	}
+
+	region, _ := tag.Region()
+
+	return region.String()
+}
+
+// Variants returns the variants of the locale (ex: "valencia" for "ca-ES-valencia").
+func (l Locale) Variants() []string {
+	variants := l.Tag.Variants()
+
+	values := make([]string, 0, len(variants))
+
+	for _, variant := range variants {
+		values = append(values, variant.String())
+	}
+
+	return values
+}
+
+// Extension returns the value of the Unicode extension key (ex: "cu", "ca", "nu").
+func (l Locale) Extension(key string) string {
+	return l.Tag.TypeForKey(key)
+}
+
+// Currency returns the currency of the locale, from the "cu" extension or from the region.
+func (l Locale) Currency() currency.Unit {
+	if cu := l.Tag.TypeForKey("cu"); cu != "" {
+		if unit, err := currency.ParseISO(cu); err == nil {
+			return unit
+		}
+	}
+
+	if region, err := language.ParseRegion(l.Region); err == nil {
+		if unit, ok := currency.FromRegion(region); ok {
+			return unit
+		}
+	}
+
+	unit, _ := currency.FromTag(l.Tag)
+
+	return unit
+}
+
+// Calendar returns the calendar of the locale from the "ca" extension.
+func (l Locale) Calendar() string {
+	if calendar := l.Tag.TypeForKey("ca"); calendar != "" {
+		return calendar
+	}
+
+	return DefaultCalendar
 }
 
 func (l Locale) String() string {
+	if l.Tag != language.Und {
+		return l.Tag.String()
+	}
+
 	return l.Language + "-" + l.Region
 }
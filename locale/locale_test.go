@@ -18,3 +18,47 @@ func TestLocaleString(t *testing.T) {
 
 	assert.Equal(t, "fr-FR", l.String())
 }
+
+func TestLocaleStringWithTag(t *testing.T) {
+	l, err := Parse("fr-CH-u-cu-eur")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "fr-CH-u-cu-eur", l.String())
+}
+
+func TestParse(t *testing.T) {
+	l, err := Parse("ca-Latn-ES-valencia-u-ca-buddhist-cu-usd")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "ca", l.Language)
+	assert.Equal(t, "Latn", l.Script)
+	assert.Equal(t, "ES", l.Region)
+	assert.Equal(t, []string{"valencia"}, l.Variants())
+	assert.Equal(t, "buddhist", l.Calendar())
+	assert.Equal(t, "USD", l.Currency().String())
+	assert.Equal(t, "usd", l.Extension("cu"))
+}
+
+func TestParseWithBadTag(t *testing.T) {
+	_, err := Parse("bad!")
+	assert.Error(t, err)
+}
+
+func TestLocaleWithRegionOverride(t *testing.T) {
+	l, err := Parse("en-u-rg-gbzzzz")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "GB", l.Region)
+	assert.Equal(t, "GBP", l.Currency().String())
+}
+
+func TestLocaleDefaults(t *testing.T) {
+	l, err := Parse("fr")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "FR", l.Region)
+	assert.Equal(t, "Latn", l.Script)
+	assert.Empty(t, l.Variants())
+	assert.Equal(t, DefaultCalendar, l.Calendar())
+	assert.Equal(t, "EUR", l.Currency().String())
+}
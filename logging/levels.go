@@ -0,0 +1,186 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-server/response"
+	"github.com/rs/zerolog"
+)
+
+// RouteLevel is the log level override of a route.
+type RouteLevel struct {
+	Route     string     `json:"route"`
+	Level     string     `json:"level"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type levelOverride struct {
+	level     zerolog.Level
+	expiresAt time.Time
+}
+
+func (o levelOverride) expired(now time.Time) bool {
+	return !o.expiresAt.IsZero() && !now.Before(o.expiresAt)
+}
+
+// Levels is a concurrency safe set of log level overrides by route template,
+// the overrides expire after their TTL.
+type Levels struct {
+	mtx    sync.RWMutex
+	routes map[string]levelOverride
+	now    func() time.Time
+}
+
+// NewLevels constructor.
+func NewLevels() *Levels {
+	return &Levels{
+		routes: make(map[string]levelOverride),
+		now:    time.Now,
+	}
+}
+
+// Set the log level of the route template for ttl, the override never expires when ttl is zero.
+// The expired overrides are pruned.
+func (l *Levels) Set(route string, level zerolog.Level, ttl time.Duration) {
+	now := l.now()
+
+	override := levelOverride{
+		level: level,
+	}
+
+	if ttl > 0 {
+		override.expiresAt = now.Add(ttl)
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	for r, o := range l.routes {
+		if o.expired(now) {
+			delete(l.routes, r)
+		}
+	}
+
+	l.routes[route] = override
+}
+
+// Delete the log level override of the route template.
+func (l *Levels) Delete(route string) {
+	l.mtx.Lock()
+	delete(l.routes, route)
+	l.mtx.Unlock()
+}
+
+// Get returns the log level override of the route template, an expired override is
+// ignored and pruned by the next Set.
+func (l *Levels) Get(route string) (zerolog.Level, bool) {
+	l.mtx.RLock()
+	override, ok := l.routes[route]
+	l.mtx.RUnlock()
+
+	if !ok || override.expired(l.now()) {
+		return zerolog.NoLevel, false
+	}
+
+	return override.level, true
+}
+
+// List returns the active log level overrides sorted by route.
+func (l *Levels) List() []RouteLevel {
+	now := l.now()
+
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	levels := make([]RouteLevel, 0, len(l.routes))
+
+	for route, override := range l.routes {
+		if override.expired(now) {
+			continue
+		}
+
+		item := RouteLevel{
+			Route: route,
+			Level: override.level.String(),
+		}
+
+		if !override.expiresAt.IsZero() {
+			expiresAt := override.expiresAt
+			item.ExpiresAt = &expiresAt
+		}
+
+		levels = append(levels, item)
+	}
+
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].Route < levels[j].Route
+	})
+
+	return levels
+}
+
+// ServeHTTP is the admin endpoint of the log level overrides, it must be protected:
+//
+//	GET                                   lists the overrides
+//	PUT ?route=/users/{id}&level=debug&ttl=10m  sets an override
+//	DELETE ?route=/users/{id}             deletes an override
+func (l *Levels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		response.Encode(w, r, http.StatusOK, l.List())
+	case http.MethodPut, http.MethodPost:
+		route := query.Get("route")
+		if route == "" {
+			response.FailureFromError(w, http.StatusBadRequest, errors.New("The route parameter is required"))
+
+			return
+		}
+
+		level, err := zerolog.ParseLevel(query.Get("level"))
+		if err != nil || level == zerolog.NoLevel {
+			response.FailureFromError(w, http.StatusBadRequest, errors.New("The level parameter is invalid"))
+
+			return
+		}
+
+		if level < zerolog.GlobalLevel() {
+			response.FailureFromError(w, http.StatusBadRequest, fmt.Errorf("The level parameter is hidden by the global log level %s", zerolog.GlobalLevel()))
+
+			return
+		}
+
+		var ttl time.Duration
+
+		if value := query.Get("ttl"); value != "" {
+			ttl, err = time.ParseDuration(value)
+			if err != nil || ttl < 0 {
+				response.FailureFromError(w, http.StatusBadRequest, errors.New("The ttl parameter is invalid"))
+
+				return
+			}
+		}
+
+		l.Set(route, level, ttl)
+
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		l.Delete(query.Get("route"))
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST, DELETE")
+
+		response.MethodNotAllowedFailure(w, r)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevels(t *testing.T) {
+	now := time.Date(2026, time.October, 10, 13, 0, 0, 0, time.UTC)
+
+	levels := NewLevels()
+	levels.now = func() time.Time {
+		return now
+	}
+
+	levels.Set("/foo", zerolog.DebugLevel, time.Minute)
+	levels.Set("/bar", zerolog.TraceLevel, 0)
+
+	level, ok := levels.Get("/foo")
+	assert.True(t, ok)
+	assert.Equal(t, zerolog.DebugLevel, level)
+
+	expiresAt := now.Add(time.Minute)
+
+	assert.Equal(t, []RouteLevel{
+		{Route: "/bar", Level: "trace"},
+		{Route: "/foo", Level: "debug", ExpiresAt: &expiresAt},
+	}, levels.List())
+
+	now = now.Add(time.Minute)
+
+	_, ok = levels.Get("/foo")
+	assert.False(t, ok, "the override expired")
+	assert.Len(t, levels.routes, 2, "the expired override is pruned by Set")
+
+	levels.Set("/baz", zerolog.InfoLevel, 0)
+
+	assert.NotContains(t, levels.routes, "/foo")
+
+	_, ok = levels.Get("/bar")
+	assert.True(t, ok)
+
+	levels.Delete("/bar")
+
+	_, ok = levels.Get("/bar")
+	assert.False(t, ok)
+
+	levels.Delete("/baz")
+
+	assert.Empty(t, levels.List())
+}
+
+func TestLevelsGetKeepsRenewedOverride(t *testing.T) {
+	now := time.Date(2026, time.October, 10, 13, 0, 0, 0, time.UTC)
+
+	levels := NewLevels()
+	levels.now = func() time.Time {
+		return now
+	}
+
+	levels.Set("/foo", zerolog.DebugLevel, time.Minute)
+
+	now = now.Add(time.Minute)
+
+	_, ok := levels.Get("/foo")
+	assert.False(t, ok)
+
+	levels.Set("/foo", zerolog.TraceLevel, time.Minute)
+
+	_, ok = levels.Get("/foo")
+	assert.True(t, ok, "reading an expired override never removes its renewal")
+}
+
+func TestLevelsServeHTTP(t *testing.T) {
+	levels := NewLevels()
+
+	w := httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/?route=/foo&level=debug&ttl=10m", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	level, ok := levels.Get("/foo")
+	assert.True(t, ok)
+	assert.Equal(t, zerolog.DebugLevel, level)
+
+	w = httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"route":"/foo","level":"debug"`)
+
+	w = httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/?route=/foo", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	_, ok = levels.Get("/foo")
+	assert.False(t, ok)
+}
+
+func TestLevelsServeHTTPWithInvalidParameters(t *testing.T) {
+	levels := NewLevels()
+
+	for _, target := range []string{
+		"/?level=debug",
+		"/?route=/foo&level=verbose",
+		"/?route=/foo",
+		"/?route=/foo&level=debug&ttl=soon",
+		"/?route=/foo&level=debug&ttl=-1m",
+	} {
+		w := httptest.NewRecorder()
+		levels.ServeHTTP(w, httptest.NewRequest(http.MethodPut, target, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+
+	w := httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestLevelsServeHTTPWithHiddenLevel(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	defer zerolog.SetGlobalLevel(zerolog.TraceLevel)
+
+	levels := NewLevels()
+
+	w := httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/?route=/foo&level=debug", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "hidden by the global log level info")
+
+	_, ok := levels.Get("/foo")
+	assert.False(t, ok)
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/euskadi31/go-server/internal/route"
+	"github.com/euskadi31/go-server/requestid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultLevelHeader is the header setting the log level of a single request.
+const DefaultLevelHeader = "X-Log-Level"
+
+// TrustFunc reports whether the request is allowed to change its log level.
+type TrustFunc func(r *http.Request) bool
+
+// Configuration struct.
+type Configuration struct {
+	// Logger is the parent of the request loggers, the global logger is used when nil.
+	Logger *zerolog.Logger
+	// LevelHeader sets the log level of the request when Trusted returns true,
+	// DefaultLevelHeader is used when empty.
+	LevelHeader string
+	// Trusted requests can set their log level with the LevelHeader, the header is ignored when nil.
+	Trusted TrustFunc
+	// Levels overrides the log level of the routes, see Levels.ServeHTTP for the admin endpoint.
+	Levels *Levels
+}
+
+// Handler adds to the request context a child logger with the route, method, request ID
+// and trace ID fields, the logger is returned by FromContext and zerolog.Ctx.
+//
+// The level of the logger is set by the LevelHeader of a trusted request, or by the level
+// of the route in Levels. The events are also filtered by zerolog.GlobalLevel, it must stay
+// at zerolog.TraceLevel (the default) and the base level must be carried by the Logger:
+//
+//	logger := log.Logger.Level(zerolog.InfoLevel)
+//
+//	logging.Handler(&logging.Configuration{Logger: &logger})
+//
+// A warning is logged when the global level hides the level of a request.
+func Handler(cfg *Configuration) func(next http.Handler) http.Handler {
+	if cfg.Logger == nil {
+		cfg.Logger = &log.Logger
+	}
+
+	if cfg.LevelHeader == "" {
+		cfg.LevelHeader = DefaultLevelHeader
+	}
+
+	var warned atomic.Bool
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			lc := cfg.Logger.With().Str("method", r.Method)
+
+			tpl, hasRoute := route.Template(r)
+			if hasRoute {
+				lc = lc.Str("route", tpl)
+			}
+
+			if id, ok := requestid.FromContext(ctx); ok {
+				lc = lc.Str("request_id", id)
+			}
+
+			if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+				lc = lc.Str("trace_id", sc.TraceID().String()).Str("span_id", sc.SpanID().String())
+			}
+
+			logger := lc.Logger()
+
+			if level, ok := cfg.level(r, tpl, hasRoute); ok {
+				logger = logger.Level(level)
+
+				if global := zerolog.GlobalLevel(); level < global && !warned.Swap(true) {
+					logger.Warn().
+						Stringer("requested_level", level).
+						Stringer("global_level", global).
+						Msg("The request log level is hidden by the global log level, set the base level on Configuration.Logger instead")
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(logger.WithContext(ctx)))
+		})
+	}
+}
+
+// level returns the log level of the request header or of the route.
+func (c *Configuration) level(r *http.Request, tpl string, hasRoute bool) (zerolog.Level, bool) {
+	if value := r.Header.Get(c.LevelHeader); value != "" && c.Trusted != nil && c.Trusted(r) {
+		level, err := zerolog.ParseLevel(value)
+		if err == nil {
+			return level, true
+		}
+
+		log.Debug().Err(err).Str("header", c.LevelHeader).Msg("Invalid log level")
+	}
+
+	if c.Levels != nil && hasRoute {
+		return c.Levels.Get(tpl)
+	}
+
+	return zerolog.NoLevel, false
+}
+
+// FromContext returns the request logger of the context, or the global logger.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	logger := zerolog.Ctx(ctx)
+	if logger.GetLevel() == zerolog.Disabled {
+		return &log.Logger
+	}
+
+	return logger
+}
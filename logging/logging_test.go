@@ -0,0 +1,153 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-server/requestid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newRouter(cfg *Configuration) *mux.Router {
+	r := mux.NewRouter()
+	r.Use(requestid.Handler(&requestid.Configuration{}))
+	r.Use(Handler(cfg))
+	r.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Debug().Msg("debug")
+		FromContext(r.Context()).Info().Msg("info")
+	})
+
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf).Level(zerolog.InfoLevel)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil).WithContext(ctx)
+	req.Header.Set("X-Request-ID", "abc-123")
+
+	newRouter(&Configuration{
+		Logger: &logger,
+	}).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.JSONEq(
+		t,
+		`{"level":"info","method":"GET","route":"/users/{id}","request_id":"abc-123","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","message":"info"}`,
+		buf.String(),
+	)
+}
+
+func TestHandlerWithTrustedLevelHeader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf).Level(zerolog.InfoLevel)
+
+	r := newRouter(&Configuration{
+		Logger: &logger,
+		Trusted: func(r *http.Request) bool {
+			return r.Header.Get("X-Debug-Token") == "s3cr3t"
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("X-Log-Level", "debug")
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, buf.String(), `"message":"debug"`, "the header of an untrusted request is ignored")
+
+	buf.Reset()
+	req.Header.Set("X-Debug-Token", "s3cr3t")
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, buf.String(), `"message":"debug"`)
+
+	buf.Reset()
+	req.Header.Set("X-Log-Level", "verbose")
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, buf.String(), `"message":"debug"`, "an invalid level is ignored")
+}
+
+func TestHandlerWithRouteLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf).Level(zerolog.InfoLevel)
+
+	levels := NewLevels()
+
+	r := newRouter(&Configuration{
+		Logger: &logger,
+		Levels: levels,
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	assert.NotContains(t, buf.String(), `"message":"debug"`)
+
+	levels.Set("/users/{id}", zerolog.DebugLevel, time.Minute)
+
+	buf.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	assert.Contains(t, buf.String(), `"message":"debug"`)
+
+	levels.Set("/users/{id}", zerolog.WarnLevel, 0)
+
+	buf.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	assert.Empty(t, buf.String())
+}
+
+func TestHandlerWithGlobalLevel(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	defer zerolog.SetGlobalLevel(zerolog.TraceLevel)
+
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf).Level(zerolog.InfoLevel)
+
+	r := newRouter(&Configuration{
+		Logger: &logger,
+		Trusted: func(r *http.Request) bool {
+			return true
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("X-Log-Level", "debug")
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, buf.String(), `"message":"debug"`)
+	assert.Contains(t, buf.String(), `"level":"warn"`)
+	assert.Contains(t, buf.String(), `"requested_level":"debug","global_level":"info"`)
+
+	buf.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, buf.String(), `"level":"warn"`, "the warning is logged once")
+}
+
+func TestFromContextWithoutLogger(t *testing.T) {
+	assert.Equal(t, &log.Logger, FromContext(context.Background()))
+}
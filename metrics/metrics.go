@@ -5,55 +5,213 @@
 package metrics
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/euskadi31/go-server/internal/countio"
+	"github.com/euskadi31/go-server/internal/route"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 	"github.com/zenazn/goji/web/mutil"
 )
 
-// Handler instanciates a new mysql HTTP handler.
-func Handler() func(http.Handler) http.Handler {
-	duration := prometheus.NewHistogram(
-		prometheus.HistogramOpts{
-			Name:    "http_request_duration_seconds",
-			Help:    "The HTTP request latencies in seconds.",
-			Buckets: []float64{0.01, 0.1, 0.3, 0.5, 1., 2., 5.},
-		},
-	)
-
-	if err := prometheus.Register(duration); err != nil {
-		log.Debug().Err(err).Msg("prometheus register duration")
+// UnmatchedRoute is the route label of the requests not matched by a mux route,
+// the request path is never used as label to bound the cardinality.
+const UnmatchedRoute = "unmatched"
+
+// OtherMethod is the method label of the non standard HTTP methods.
+const OtherMethod = "OTHER"
+
+type collectors struct {
+	duration     *prometheus.HistogramVec
+	requestSize  *prometheus.HistogramVec
+	responseSize *prometheus.HistogramVec
+	inFlight     *prometheus.GaugeVec
+	request      *prometheus.CounterVec
+}
+
+func newCollectors(opts *Options) *collectors {
+	labels := []string{"route", "method", "status"}
+
+	return &collectors{
+		duration: prometheus.NewHistogramVec(
+			opts.histogramOpts("http_request_duration_seconds", "The HTTP request latencies in seconds.", opts.DurationBuckets),
+			labels,
+		),
+		requestSize: prometheus.NewHistogramVec(
+			opts.histogramOpts("http_request_size_bytes", "The HTTP request body sizes in bytes.", opts.SizeBuckets),
+			labels,
+		),
+		responseSize: prometheus.NewHistogramVec(
+			opts.histogramOpts("http_response_size_bytes", "The HTTP response body sizes in bytes.", opts.SizeBuckets),
+			labels,
+		),
+		inFlight: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   opts.Namespace,
+				Subsystem:   opts.Subsystem,
+				Name:        "http_requests_in_flight",
+				Help:        "The count of requests being served.",
+				ConstLabels: opts.ConstLabels,
+			},
+			[]string{"route", "method"},
+		),
+		request: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   opts.Namespace,
+				Subsystem:   opts.Subsystem,
+				Name:        "http_request_total",
+				Help:        "The count of request.",
+				ConstLabels: opts.ConstLabels,
+			},
+			[]string{"status", "method"},
+		),
+	}
+}
+
+// register the collectors, they are unregistered on failure.
+func (c *collectors) register(reg prometheus.Registerer) error {
+	all := []prometheus.Collector{c.duration, c.requestSize, c.responseSize, c.inFlight, c.request}
+
+	for i, collector := range all {
+		if err := reg.Register(collector); err != nil {
+			for _, registered := range all[:i] {
+				reg.Unregister(registered)
+			}
+
+			return fmt.Errorf("failed to register metrics: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// shared returns the collectors already registered in the default registry by a previous Handler.
+func (c *collectors) shared() *collectors {
+	return &collectors{
+		duration:     registerOrExisting(c.duration).(*prometheus.HistogramVec),     // nolint: forcetypeassert
+		requestSize:  registerOrExisting(c.requestSize).(*prometheus.HistogramVec),  // nolint: forcetypeassert
+		responseSize: registerOrExisting(c.responseSize).(*prometheus.HistogramVec), // nolint: forcetypeassert
+		inFlight:     registerOrExisting(c.inFlight).(*prometheus.GaugeVec),         // nolint: forcetypeassert
+		request:      registerOrExisting(c.request).(*prometheus.CounterVec),        // nolint: forcetypeassert
 	}
+}
 
-	request := prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "http_request_total",
-			Help: "The count of request.",
-		},
-		[]string{"status", "method"},
-	)
+func registerOrExisting(c prometheus.Collector) prometheus.Collector {
+	if err := prometheus.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			return are.ExistingCollector
+		}
 
-	if err := prometheus.Register(request); err != nil {
-		log.Debug().Err(err).Msg("prometheus register request")
+		log.Error().Err(err).Msg("prometheus register")
 	}
 
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			lw := mutil.WrapWriter(w)
+	return c
+}
+
+// Handler instanciates a new metrics HTTP handler registered in the default registry,
+// the metrics are shared by all the handlers of the process.
+// The metrics are labeled by the mux route template, so it must be used as a middleware of the mux router.
+func Handler() func(http.Handler) http.Handler {
+	opts := &Options{}
+	opts.withDefaults()
+
+	return newCollectors(opts).shared().handler
+}
 
-			ts := time.Now()
+// HandlerWithOptions instanciates a new metrics HTTP handler registered in opts.Registerer,
+// an error is returned if the metrics are already registered.
+func HandlerWithOptions(opts Options) (func(http.Handler) http.Handler, error) {
+	opts.withDefaults()
 
-			next.ServeHTTP(lw, r)
+	c := newCollectors(&opts)
 
-			duration.Observe(time.Since(ts).Seconds())
+	if err := c.register(opts.Registerer); err != nil {
+		return nil, err
+	}
+
+	return c.handler, nil
+}
+
+// Exporter returns the HTTP handler serving the metrics of opts.Gatherer.
+func Exporter(opts Options) http.Handler {
+	opts.withDefaults()
+
+	return promhttp.InstrumentMetricHandler(opts.Registerer, promhttp.HandlerFor(opts.Gatherer, promhttp.HandlerOpts{}))
+}
+
+func (c *collectors) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tpl, ok := route.Template(r)
+		if !ok {
+			tpl = UnmatchedRoute
+		}
+
+		method := methodLabel(r.Method)
+
+		gauge := c.inFlight.WithLabelValues(tpl, method)
+		gauge.Inc()
+
+		defer gauge.Dec()
+
+		body := countio.NewReader(r.Body)
+
+		if r.Body != nil && r.ContentLength < 0 {
+			r.Body = body
+		}
+
+		lw := mutil.WrapWriter(w)
+
+		ts := time.Now()
+
+		next.ServeHTTP(lw, r)
+
+		status := lw.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		size := r.ContentLength
+		if size < 0 {
+			size = body.Count()
+		}
+
+		class := statusClass(status)
+
+		c.duration.WithLabelValues(tpl, method, class).Observe(time.Since(ts).Seconds())
+		c.requestSize.WithLabelValues(tpl, method, class).Observe(float64(size))
+		c.responseSize.WithLabelValues(tpl, method, class).Observe(float64(lw.BytesWritten()))
+
+		c.request.With(prometheus.Labels{
+			"status": strconv.FormatInt(int64(status), 10),
+			"method": method,
+		}).Add(1)
+	})
+}
+
+var statusClasses = [...]string{"1xx", "2xx", "3xx", "4xx", "5xx"}
+
+// statusClass returns the status class label of the status code.
+func statusClass(status int) string {
+	if i := status / 100; i >= 1 && i <= 5 {
+		return statusClasses[i-1]
+	}
+
+	return "unknown"
+}
 
-			request.With(prometheus.Labels{
-				"status": strconv.FormatInt(int64(lw.Status()), 10),
-				"method": r.Method,
-			}).Add(1)
-		})
+// methodLabel bounds the cardinality of the method label to the standard methods.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return OtherMethod
 	}
 }
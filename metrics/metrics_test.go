@@ -5,11 +5,16 @@
 package metrics
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,3 +32,248 @@ func TestHandler(t *testing.T) {
 
 	assert.Equal(t, 200, w.Code)
 }
+
+// findMetric returns the metric of the family matching all the labels.
+func findMetric(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+	next:
+		for _, metric := range family.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if value, ok := labels[pair.GetName()]; ok && value != pair.GetValue() {
+					continue next
+				}
+			}
+
+			return metric
+		}
+	}
+
+	return nil
+}
+
+func TestHandlerWithRoute(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Handler())
+
+	router.HandleFunc("/articles/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		gauge := findMetric(t, "http_requests_in_flight", map[string]string{
+			"route":  "/articles/{id}",
+			"method": http.MethodPut,
+		})
+		assert.NotNil(t, gauge)
+		assert.Equal(t, 1.0, gauge.GetGauge().GetValue())
+
+		w.WriteHeader(http.StatusCreated)
+
+		_, _ = w.Write(body)
+		_, _ = w.Write(body)
+	}).Methods(http.MethodPut)
+
+	for _, id := range []string{"1", "2", "3"} {
+		req := httptest.NewRequest(http.MethodPut, "http://example.com/articles/"+id, strings.NewReader("hello"))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	labels := map[string]string{
+		"route":  "/articles/{id}",
+		"method": http.MethodPut,
+		"status": "2xx",
+	}
+
+	duration := findMetric(t, "http_request_duration_seconds", labels)
+	assert.NotNil(t, duration)
+	assert.Equal(t, uint64(3), duration.GetHistogram().GetSampleCount())
+
+	requestSize := findMetric(t, "http_request_size_bytes", labels)
+	assert.NotNil(t, requestSize)
+	assert.Equal(t, 15.0, requestSize.GetHistogram().GetSampleSum())
+
+	responseSize := findMetric(t, "http_response_size_bytes", labels)
+	assert.NotNil(t, responseSize)
+	assert.Equal(t, 30.0, responseSize.GetHistogram().GetSampleSum())
+
+	gauge := findMetric(t, "http_requests_in_flight", map[string]string{
+		"route":  "/articles/{id}",
+		"method": http.MethodPut,
+	})
+	assert.NotNil(t, gauge)
+	assert.Equal(t, 0.0, gauge.GetGauge().GetValue())
+}
+
+func TestHandlerWithUnmatchedRoute(t *testing.T) {
+	middleware := Handler()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		http.NotFound(w, r)
+	}))
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		req := httptest.NewRequest("PURGE", "http://example.com"+path, io.NopCloser(strings.NewReader("abcd")))
+		req.ContentLength = -1
+
+		middleware.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	requestSize := findMetric(t, "http_request_size_bytes", map[string]string{
+		"route":  UnmatchedRoute,
+		"method": OtherMethod,
+		"status": "4xx",
+	})
+	assert.NotNil(t, requestSize)
+	assert.Equal(t, uint64(3), requestSize.GetHistogram().GetSampleCount())
+	assert.Equal(t, 12.0, requestSize.GetHistogram().GetSampleSum())
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "1xx", statusClass(http.StatusContinue))
+	assert.Equal(t, "2xx", statusClass(http.StatusOK))
+	assert.Equal(t, "3xx", statusClass(http.StatusFound))
+	assert.Equal(t, "4xx", statusClass(http.StatusNotFound))
+	assert.Equal(t, "5xx", statusClass(http.StatusBadGateway))
+	assert.Equal(t, "unknown", statusClass(600))
+}
+
+func TestMethodLabel(t *testing.T) {
+	assert.Equal(t, http.MethodGet, methodLabel(http.MethodGet))
+	assert.Equal(t, OtherMethod, methodLabel("PROPFIND"))
+}
+
+func TestHandlerWithOptions(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	opts := Options{
+		Namespace: "app",
+		Subsystem: "api",
+		ConstLabels: prometheus.Labels{
+			"router": "admin",
+		},
+		DurationBuckets: []float64{0.1, 1},
+		SizeBuckets:     []float64{},
+		NativeHistograms: &NativeHistogramOptions{
+			BucketFactor: 1.5,
+		},
+		Registerer: registry,
+	}
+
+	handler, err := HandlerWithOptions(opts)
+	assert.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Use(handler)
+	router.HandleFunc("/foo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "foo")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+
+	metrics := map[string]*dto.Metric{}
+
+	for _, family := range families {
+		metrics[family.GetName()] = family.GetMetric()[0]
+	}
+
+	assert.Contains(t, metrics, "app_api_http_request_duration_seconds")
+	assert.Contains(t, metrics, "app_api_http_request_size_bytes")
+	assert.Contains(t, metrics, "app_api_http_response_size_bytes")
+	assert.Contains(t, metrics, "app_api_http_requests_in_flight")
+	assert.Contains(t, metrics, "app_api_http_request_total")
+
+	duration := metrics["app_api_http_request_duration_seconds"]
+	assert.Len(t, duration.GetHistogram().GetBucket(), 2)
+	assert.Equal(t, int32(1), duration.GetHistogram().GetSchema())
+
+	responseSize := metrics["app_api_http_response_size_bytes"]
+	assert.Empty(t, responseSize.GetHistogram().GetBucket())
+	assert.Equal(t, 3.0, responseSize.GetHistogram().GetSampleSum())
+
+	labels := map[string]string{}
+
+	for _, pair := range duration.GetLabel() {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+
+	assert.Equal(t, map[string]string{
+		"router": "admin",
+		"route":  "/foo",
+		"method": http.MethodGet,
+		"status": "2xx",
+	}, labels)
+
+	// The duplicate registration is reported.
+	_, err = HandlerWithOptions(opts)
+	assert.Error(t, err)
+
+	var are prometheus.AlreadyRegisteredError
+	assert.ErrorAs(t, err, &are)
+
+	// A registry per router.
+	_, err = HandlerWithOptions(Options{
+		Registerer: prometheus.NewRegistry(),
+	})
+	assert.NoError(t, err)
+}
+
+func TestHandlerWithOptionsPartialRegistration(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	conflict := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_request_total",
+		Help: "The count of request.",
+	}, []string{"status", "method"})
+
+	registry.MustRegister(conflict)
+
+	_, err := HandlerWithOptions(Options{
+		Registerer: registry,
+	})
+	assert.Error(t, err)
+
+	// The collectors registered before the failure are unregistered.
+	registry.Unregister(conflict)
+
+	_, err = HandlerWithOptions(Options{
+		Registerer: registry,
+	})
+	assert.NoError(t, err)
+}
+
+func TestExporter(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	handler, err := HandlerWithOptions(Options{
+		Registerer: registry,
+	})
+	assert.NoError(t, err)
+
+	handler(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	w := httptest.NewRecorder()
+
+	Exporter(Options{
+		Registerer: registry,
+	}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `http_request_total{method="GET",status="404"} 1`)
+	assert.Contains(t, w.Body.String(), `promhttp_metric_handler_requests_total`)
+	assert.NotContains(t, w.Body.String(), `go_goroutines`)
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// DefaultDurationBuckets of the request duration histogram in seconds.
+	DefaultDurationBuckets = []float64{0.01, 0.1, 0.3, 0.5, 1., 2., 5.}
+
+	// DefaultSizeBuckets of the request and response size histograms in bytes (100B to 100MB).
+	DefaultSizeBuckets = prometheus.ExponentialBuckets(100, 10, 7)
+
+	// DefaultNativeHistogramBucketFactor sets the growth factor of the native histogram buckets (1.1).
+	DefaultNativeHistogramBucketFactor = 1.1
+
+	// DefaultNativeHistogramMaxBucketNumber sets the maximum number of native histogram buckets (160).
+	DefaultNativeHistogramMaxBucketNumber uint32 = 160
+
+	// DefaultNativeHistogramMinResetDuration sets the minimum duration between two resets
+	// of the native histogram buckets when MaxBucketNumber is reached (1h).
+	DefaultNativeHistogramMinResetDuration = time.Hour
+)
+
+// Options of the metrics Handler.
+type Options struct {
+	Namespace   string
+	Subsystem   string
+	ConstLabels prometheus.Labels
+	// DurationBuckets of the request duration histogram, DefaultDurationBuckets is used when nil.
+	DurationBuckets []float64
+	// SizeBuckets of the request and response size histograms, DefaultSizeBuckets is used when nil.
+	SizeBuckets []float64
+	// NativeHistograms enables the native histograms in addition to the classic buckets,
+	// set the buckets to an empty slice to only expose the native histograms.
+	NativeHistograms *NativeHistogramOptions
+	// Registerer of the metrics, prometheus.DefaultRegisterer is used when nil.
+	Registerer prometheus.Registerer
+	// Gatherer of the metrics endpoint, the Registerer is used when it is a prometheus.Gatherer
+	// (ex: *prometheus.Registry), prometheus.DefaultGatherer otherwise.
+	Gatherer prometheus.Gatherer
+}
+
+// NativeHistogramOptions struct, the zero values are replaced by the defaults.
+type NativeHistogramOptions struct {
+	BucketFactor     float64
+	MaxBucketNumber  uint32
+	MinResetDuration time.Duration
+}
+
+func (o *Options) withDefaults() {
+	if o.DurationBuckets == nil {
+		o.DurationBuckets = DefaultDurationBuckets
+	}
+
+	if o.SizeBuckets == nil {
+		o.SizeBuckets = DefaultSizeBuckets
+	}
+
+	if o.Registerer == nil {
+		o.Registerer = prometheus.DefaultRegisterer
+	}
+
+	if o.Gatherer == nil {
+		if gatherer, ok := o.Registerer.(prometheus.Gatherer); ok {
+			o.Gatherer = gatherer
+		} else {
+			o.Gatherer = prometheus.DefaultGatherer
+		}
+	}
+
+	if o.NativeHistograms != nil {
+		if o.NativeHistograms.BucketFactor <= 1 {
+			o.NativeHistograms.BucketFactor = DefaultNativeHistogramBucketFactor
+		}
+
+		if o.NativeHistograms.MaxBucketNumber == 0 {
+			o.NativeHistograms.MaxBucketNumber = DefaultNativeHistogramMaxBucketNumber
+		}
+
+		if o.NativeHistograms.MinResetDuration == 0 {
+			o.NativeHistograms.MinResetDuration = DefaultNativeHistogramMinResetDuration
+		}
+	}
+}
+
+func (o *Options) histogramOpts(name string, help string, buckets []float64) prometheus.HistogramOpts {
+	opts := prometheus.HistogramOpts{
+		Namespace:   o.Namespace,
+		Subsystem:   o.Subsystem,
+		Name:        name,
+		Help:        help,
+		ConstLabels: o.ConstLabels,
+		Buckets:     buckets,
+	}
+
+	if o.NativeHistograms != nil {
+		opts.NativeHistogramBucketFactor = o.NativeHistograms.BucketFactor
+		opts.NativeHistogramMaxBucketNumber = o.NativeHistograms.MaxBucketNumber
+		opts.NativeHistogramMinResetDuration = o.NativeHistograms.MinResetDuration
+	}
+
+	return opts
+}
@@ -5,14 +5,18 @@
 package opentracing
 
 import (
-	"bytes"
 	"fmt"
+	"net"
 	"net/http"
+	"runtime/debug"
 	"strings"
 
+	"github.com/euskadi31/go-server/internal/countio"
+	"github.com/euskadi31/go-server/internal/route"
 	"github.com/gorilla/mux"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/rs/zerolog/log"
 	"github.com/zenazn/goji/web/mutil"
 )
@@ -26,11 +30,43 @@ func IgnoreNone(*http.Request) bool {
 	return false
 }
 
+// SpanDecoratorFunc is called with the server span before the request is handled,
+// it can add tags (ex: tenant ID) or set the sampling priority with ext.SamplingPriority.
+type SpanDecoratorFunc func(span opentracing.Span, r *http.Request)
+
+// Configuration struct.
+type Configuration struct {
+	// Tracer starts the spans, opentracing.GlobalTracer() is used when nil.
+	Tracer opentracing.Tracer
+	// Ignore the requests to not trace, IgnoreNone is used when nil.
+	Ignore RequestIgnorerFunc
+	// Decorate the server span of each request.
+	Decorate SpanDecoratorFunc
+}
+
 // Handler opentracing.
 func Handler(tracer opentracing.Tracer, ignore RequestIgnorerFunc) func(next http.Handler) http.Handler {
+	return HandlerWithConfiguration(&Configuration{
+		Tracer: tracer,
+		Ignore: ignore,
+	})
+}
+
+// HandlerWithConfiguration returns the opentracing middleware, the server span is tagged with
+// the request and response sizes, the client address, the user agent and the error of
+// the 5xx responses and panics.
+func HandlerWithConfiguration(cfg *Configuration) func(next http.Handler) http.Handler {
+	if cfg.Tracer == nil {
+		cfg.Tracer = opentracing.GlobalTracer()
+	}
+
+	if cfg.Ignore == nil {
+		cfg.Ignore = IgnoreNone
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			if ignore(req) {
+			if cfg.Ignore(req) {
 				next.ServeHTTP(w, req)
 
 				return
@@ -38,7 +74,7 @@ func Handler(tracer opentracing.Tracer, ignore RequestIgnorerFunc) func(next htt
 
 			lw := mutil.WrapWriter(w)
 
-			wireContext, err := tracer.Extract(
+			wireContext, err := cfg.Tracer.Extract(
 				opentracing.HTTPHeaders,
 				opentracing.HTTPHeadersCarrier(req.Header),
 			)
@@ -48,119 +84,171 @@ func Handler(tracer opentracing.Tracer, ignore RequestIgnorerFunc) func(next htt
 
 			path := routeRequestName(req)
 
-			span := tracer.StartSpan(path, ext.RPCServerOption(wireContext))
-			defer span.Finish()
+			span := cfg.Tracer.StartSpan(path, ext.RPCServerOption(wireContext))
 
-			ext.HTTPUrl.Set(span, fmt.Sprintf("http://%s%s", req.Host, req.URL.Path))
+			ext.Component.Set(span, "net/http")
+			ext.HTTPUrl.Set(span, fmt.Sprintf("%s://%s%s", requestScheme(req), req.Host, req.URL.Path))
 			ext.HTTPMethod.Set(span, req.Method)
+			setPeerAddress(span, req)
+
+			if ua := req.UserAgent(); ua != "" {
+				span.SetTag("http.user_agent", ua)
+			}
 
 			params := mux.Vars(req)
 			for k, v := range params {
 				span.SetTag(k, v)
 			}
 
+			if cfg.Decorate != nil {
+				cfg.Decorate(span, req)
+			}
+
+			body := countio.NewReader(req.Body)
+
+			if req.Body != nil && req.ContentLength < 0 {
+				req.Body = body
+			}
+
 			ctx := req.Context()
 			ctx = opentracing.ContextWithSpan(ctx, span)
 
 			req = req.WithContext(ctx)
 
+			defer func() {
+				if rec := recover(); rec != nil {
+					ext.HTTPStatusCode.Set(span, http.StatusInternalServerError)
+					span.SetTag("result", statusCodeResult(http.StatusInternalServerError))
+					ext.Error.Set(span, true)
+					span.LogFields(
+						otlog.String("event", "error"),
+						otlog.String("error.kind", "panic"),
+						otlog.String("message", fmt.Sprint(rec)),
+						otlog.String("stack", string(debug.Stack())),
+					)
+					span.Finish()
+
+					panic(rec)
+				}
+			}()
+
 			next.ServeHTTP(lw, req)
 
-			ext.HTTPStatusCode.Set(span, uint16(lw.Status())) // nolint: gosec
+			status := lw.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
 
-			span.SetTag("result", statusCodeResult(lw.Status()))
+			ext.HTTPStatusCode.Set(span, uint16(status)) // nolint: gosec
+
+			span.SetTag("result", statusCodeResult(status))
+			size := req.ContentLength
+			if size < 0 {
+				size = body.Count()
+			}
+
+			span.SetTag("http.request_size", size)
+			span.SetTag("http.response_size", lw.BytesWritten())
+
+			if status >= http.StatusInternalServerError {
+				ext.Error.Set(span, true)
+				span.LogFields(
+					otlog.String("event", "error"),
+					otlog.String("error.kind", "http"),
+					otlog.String("message", http.StatusText(status)),
+				)
+			}
+
+			span.Finish()
 		})
 	}
 }
 
-func routeRequestName(req *http.Request) string {
-	route := mux.CurrentRoute(req)
-	if route != nil {
-		tpl, err := route.GetPathTemplate()
-		if err == nil {
-			return req.Method + " " + massageTemplate(tpl)
-		}
+// requestScheme returns the scheme of the request, the X-Forwarded-Proto header
+// is used when the request has not been updated by the proxy middleware.
+func requestScheme(req *http.Request) string {
+	switch {
+	case req.URL.Scheme != "":
+		return req.URL.Scheme
+	case req.TLS != nil:
+		return "https"
 	}
 
-	return serverRequestName(req)
-}
-
-func serverRequestName(req *http.Request) string {
-	var b strings.Builder
-
-	b.Grow(len(req.Method) + len(req.URL.Path) + 1)
-	b.WriteString(req.Method)
-	b.WriteByte(' ')
-	b.WriteString(req.URL.Path)
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		return proto
+	}
 
-	return b.String()
+	return "http"
 }
 
-// massageTemplate removes the regexp patterns from template variables.
-func massageTemplate(tpl string) string {
-	braces := braceIndices(tpl)
+// clientAddress returns the address of the client from the RFC7239 Forwarded, X-Forwarded-For
+// and X-Real-IP headers, or the remote address of the connection.
+func clientAddress(req *http.Request) string {
+	if fwd := req.Header.Get("Forwarded"); fwd != "" {
+		for _, pair := range strings.Split(strings.SplitN(fwd, ",", 2)[0], ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && strings.EqualFold(name, "for") {
+				value = strings.Trim(value, `"`)
 
-	if len(braces) == 0 {
-		return tpl
-	}
+				if host, _, err := net.SplitHostPort(value); err == nil {
+					return strings.Trim(host, "[]")
+				}
 
-	buf := bytes.NewBuffer(make([]byte, 0, len(tpl)))
+				return strings.Trim(value, "[]")
+			}
+		}
+	}
 
-	for i := 0; i < len(tpl); {
-		var j int
+	if xff := req.Header.Get("X-Forwarded-For"); xff != "" {
+		return strings.TrimSpace(strings.SplitN(xff, ",", 2)[0])
+	}
 
-		if i < braces[0] {
-			j = braces[0]
+	if ip := req.Header.Get("X-Real-IP"); ip != "" {
+		return strings.TrimSpace(ip)
+	}
 
-			buf.WriteString(tpl[i:j])
-		} else {
-			j = braces[1]
-			field := tpl[i:j]
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		return host
+	}
 
-			if colon := strings.IndexRune(field, ':'); colon >= 0 {
-				buf.WriteString(field[:colon])
-				buf.WriteRune('}')
-			} else {
-				buf.WriteString(field)
-			}
+	return req.RemoteAddr
+}
 
-			braces = braces[2:]
+func setPeerAddress(span opentracing.Span, req *http.Request) {
+	addr := clientAddress(req)
+	if addr == "" {
+		return
+	}
 
-			if len(braces) == 0 {
-				buf.WriteString(tpl[j:])
+	ip := net.ParseIP(addr)
 
-				break
-			}
-		}
+	switch {
+	case ip == nil:
+		ext.PeerHostname.Set(span, addr)
+	case ip.To4() != nil:
+		ext.PeerHostIPv4.SetString(span, addr)
+	default:
+		ext.PeerHostIPv6.Set(span, addr)
+	}
+}
 
-		i = j
+func routeRequestName(req *http.Request) string {
+	if tpl, ok := route.Template(req); ok {
+		return req.Method + " " + tpl
 	}
 
-	return buf.String()
+	return serverRequestName(req)
 }
 
-// Copied/adapted from gorilla/mux. The original version checks
-// that the braces are matched up correctly; we assume they are,
-// as otherwise the path wouldn't have been registered correctly.
-func braceIndices(s string) []int {
-	var level, idx int
-
-	var idxs []int
+func serverRequestName(req *http.Request) string {
+	var b strings.Builder
 
-	for i := 0; i < len(s); i++ {
-		switch s[i] {
-		case '{':
-			if level++; level == 1 {
-				idx = i
-			}
-		case '}':
-			if level--; level == 0 {
-				idxs = append(idxs, idx, i+1)
-			}
-		}
-	}
+	b.Grow(len(req.Method) + len(req.URL.Path) + 1)
+	b.WriteString(req.Method)
+	b.WriteByte(' ')
+	b.WriteString(req.URL.Path)
 
-	return idxs
+	return b.String()
 }
 
 var standardStatusCodeResults = [...]string{
@@ -5,13 +5,17 @@
 package opentracing
 
 import (
+	"crypto/tls"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
 	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 )
@@ -68,16 +72,6 @@ func TestIgnoreNone(t *testing.T) {
 	assert.False(t, IgnoreNone(req))
 }
 
-func TestMassageTemplate(t *testing.T) {
-	out := massageTemplate("/articles/{category}/{id:[0-9]+}")
-
-	assert.Equal(t, "/articles/{category}/{id}", out)
-
-	out = massageTemplate("/articles")
-
-	assert.Equal(t, "/articles", out)
-}
-
 func TestServerRequestName(t *testing.T) {
 	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
 
@@ -106,3 +100,140 @@ func TestRouteRequestNameWithContext(t *testing.T) {
 func TestStatusCodeResult(t *testing.T) {
 	assert.Equal(t, "HTTP 2xx", statusCodeResult(http.StatusOK))
 }
+
+func TestHandlerWithConfiguration(t *testing.T) {
+	req := httptest.NewRequest("POST", "https://example.com/foo", io.NopCloser(strings.NewReader("hello")))
+	req.Header.Set("User-Agent", "test/1.0")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	w := httptest.NewRecorder()
+
+	tracer := mocktracer.New()
+
+	middleware := alice.New(HandlerWithConfiguration(&Configuration{
+		Tracer: tracer,
+		Decorate: func(span opentracing.Span, r *http.Request) {
+			span.SetTag("tenant.id", "acme")
+		},
+	})).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("created"))
+	})
+
+	middleware.ServeHTTP(w, req)
+
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 1)
+
+	span := spans[0]
+
+	assert.Equal(t, "https://example.com/foo", span.Tag("http.url"))
+	assert.Equal(t, "test/1.0", span.Tag("http.user_agent"))
+	assert.Equal(t, "203.0.113.7", span.Tag("peer.ipv4"))
+	assert.Equal(t, "acme", span.Tag("tenant.id"))
+	assert.Equal(t, int64(5), span.Tag("http.request_size"))
+	assert.Equal(t, 7, span.Tag("http.response_size"))
+	assert.Equal(t, uint16(http.StatusCreated), span.Tag("http.status_code"))
+	assert.Equal(t, "HTTP 2xx", span.Tag("result"))
+	assert.Nil(t, span.Tag("error"))
+}
+
+func TestHandlerWithServerError(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	tracer := mocktracer.New()
+
+	middleware := alice.New(Handler(tracer, IgnoreNone)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	middleware.ServeHTTP(w, req)
+
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 1)
+
+	assert.Equal(t, true, spans[0].Tag("error"))
+	assert.Equal(t, uint16(http.StatusServiceUnavailable), spans[0].Tag("http.status_code"))
+	assert.Len(t, spans[0].Logs(), 1)
+	assert.Equal(t, "Service Unavailable", spans[0].Logs()[0].Fields[2].ValueString)
+}
+
+func TestHandlerWithPanic(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	tracer := mocktracer.New()
+
+	middleware := alice.New(Handler(tracer, IgnoreNone)).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	assert.PanicsWithValue(t, "boom", func() {
+		middleware.ServeHTTP(w, req)
+	})
+
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 1)
+
+	assert.Equal(t, true, spans[0].Tag("error"))
+	assert.Equal(t, uint16(http.StatusInternalServerError), spans[0].Tag("http.status_code"))
+	assert.Len(t, spans[0].Logs(), 1)
+
+	fields := spans[0].Logs()[0].Fields
+
+	assert.Equal(t, "panic", fields[1].ValueString)
+	assert.Equal(t, "boom", fields[2].ValueString)
+}
+
+func TestHandlerWithSamplingPriority(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	w := httptest.NewRecorder()
+
+	tracer := mocktracer.New()
+
+	middleware := alice.New(HandlerWithConfiguration(&Configuration{
+		Tracer: tracer,
+		Decorate: func(span opentracing.Span, r *http.Request) {
+			ext.SamplingPriority.Set(span, 0)
+		},
+	})).ThenFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	middleware.ServeHTTP(w, req)
+
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 1)
+
+	ctx := spans[0].Context().(mocktracer.MockSpanContext) // nolint: forcetypeassert
+
+	assert.False(t, ctx.Sampled)
+}
+
+func TestRequestScheme(t *testing.T) {
+	req := httptest.NewRequest("GET", "/foo", nil)
+	assert.Equal(t, "http", requestScheme(req))
+
+	req.Header.Set("X-Forwarded-Proto", "https")
+	assert.Equal(t, "https", requestScheme(req))
+
+	req = httptest.NewRequest("GET", "/foo", nil)
+	req.TLS = &tls.ConnectionState{}
+	assert.Equal(t, "https", requestScheme(req))
+}
+
+func TestClientAddress(t *testing.T) {
+	req := httptest.NewRequest("GET", "/foo", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	assert.Equal(t, "192.0.2.1", clientAddress(req))
+
+	req.Header.Set("X-Real-IP", "198.51.100.2")
+	assert.Equal(t, "198.51.100.2", clientAddress(req))
+
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	assert.Equal(t, "203.0.113.7", clientAddress(req))
+
+	req.Header.Set("Forwarded", `for="[2001:db8::1]:4711";proto=https, for=10.0.0.1`)
+	assert.Equal(t, "2001:db8::1", clientAddress(req))
+}
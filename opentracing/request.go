@@ -19,6 +19,9 @@ import (
 // WrapRequest injects an OpenTracing Span found in
 // context into the HTTP Headers. If no such Span can be found, WrapRequest
 // is a noop.
+//
+// Deprecated: WrapRequest turns the span of the context into a client span and uses
+// the global tracer, use Transport to trace the outbound requests with child spans.
 func WrapRequest(ctx context.Context, req *http.Request) *http.Request {
 	// Retrieve the Span from context.
 	span := opentracing.SpanFromContext(ctx)
@@ -37,15 +40,7 @@ func WrapRequest(ctx context.Context, req *http.Request) *http.Request {
 	)
 
 	// Add information on the peer service we're about to contact.
-	if host, portString, err := net.SplitHostPort(req.URL.Host); err == nil {
-		ext.PeerHostname.Set(span, host)
-
-		if port, err := strconv.Atoi(portString); err == nil {
-			ext.PeerPort.Set(span, uint16(port)) // nolint: gosec
-		}
-	} else {
-		ext.PeerHostname.Set(span, req.URL.Host)
-	}
+	setPeerTags(span, req.URL.Host)
 
 	// Inject the Span context into the outgoing HTTP Request.
 	if err := opentracing.GlobalTracer().Inject(
@@ -58,3 +53,19 @@ func WrapRequest(ctx context.Context, req *http.Request) *http.Request {
 
 	return req
 }
+
+// setPeerTags sets the peer hostname and port tags from a host with an optional port.
+func setPeerTags(span opentracing.Span, hostPort string) {
+	host, portString, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		ext.PeerHostname.Set(span, hostPort)
+
+		return
+	}
+
+	ext.PeerHostname.Set(span, host)
+
+	if port, err := strconv.Atoi(portString); err == nil {
+		ext.PeerPort.Set(span, uint16(port)) // nolint: gosec
+	}
+}
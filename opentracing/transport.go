@@ -0,0 +1,275 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package opentracing
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/rs/zerolog/log"
+)
+
+type key int
+
+const (
+	attemptContextKey key = iota
+)
+
+// WithAttempt adds the attempt number of a retried request to the context,
+// it is set as the "http.attempt" tag of the client span.
+func WithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptContextKey, attempt)
+}
+
+// Transport is a http.RoundTripper tracing each outbound request with a client span,
+// child of the span found in the request context. The span is finished when the
+// response body is closed.
+//
+//	client := &http.Client{
+//		Transport: opentracing.NewTransport(tracer, http.DefaultTransport),
+//	}
+type Transport struct {
+	// Base is the http.RoundTripper performing the requests, http.DefaultTransport is used when nil.
+	Base http.RoundTripper
+	// Tracer starts the spans and injects their context in the headers,
+	// opentracing.GlobalTracer() is used when nil.
+	Tracer opentracing.Tracer
+	// OperationName returns the span name, "HTTP {method}" is used when nil.
+	OperationName func(req *http.Request) string
+}
+
+// NewTransport constructor.
+func NewTransport(tracer opentracing.Tracer, base http.RoundTripper) *Transport {
+	return &Transport{
+		Base:   base,
+		Tracer: tracer,
+	}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+
+	return http.DefaultTransport
+}
+
+func (t *Transport) tracer() opentracing.Tracer {
+	if t.Tracer != nil {
+		return t.Tracer
+	}
+
+	return opentracing.GlobalTracer()
+}
+
+func (t *Transport) operationName(req *http.Request) string {
+	if t.OperationName != nil {
+		return t.OperationName(req)
+	}
+
+	return "HTTP " + req.Method
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tracer := t.tracer()
+
+	opts := []opentracing.StartSpanOption{
+		ext.SpanKindRPCClient,
+	}
+
+	if parent := opentracing.SpanFromContext(req.Context()); parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+
+	span := tracer.StartSpan(t.operationName(req), opts...)
+
+	ext.Component.Set(span, "net/http")
+	ext.HTTPMethod.Set(span, req.Method)
+	ext.HTTPUrl.Set(span, req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+	setPeerTags(span, req.URL.Host)
+
+	if attempt, ok := req.Context().Value(attemptContextKey).(int); ok {
+		span.SetTag("http.attempt", attempt)
+	}
+
+	ct := &clientTrace{
+		span: span,
+	}
+
+	ctx := opentracing.ContextWithSpan(req.Context(), span)
+	ctx = httptrace.WithClientTrace(ctx, ct.trace())
+
+	// A RoundTripper must not modify the request.
+	req = req.Clone(ctx)
+
+	if err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header)); err != nil {
+		log.Error().Err(err).Msg("trying to inject span")
+	}
+
+	resp, err := t.base().RoundTrip(req)
+
+	if retries := ct.retries(); retries > 0 {
+		span.SetTag("http.retries", retries)
+	}
+
+	if err != nil {
+		ext.LogError(span, err)
+		span.Finish()
+
+		return nil, err // nolint: wrapcheck
+	}
+
+	ext.HTTPStatusCode.Set(span, uint16(resp.StatusCode)) // nolint: gosec
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		ext.Error.Set(span, true)
+	}
+
+	resp.Body = wrapBody(resp.Body, span)
+
+	return resp, nil
+}
+
+// clientTrace logs the connection timing events on the span.
+type clientTrace struct {
+	span     opentracing.Span
+	getConns int32
+}
+
+func (c *clientTrace) event(name string, fields ...otlog.Field) {
+	c.span.LogFields(append([]otlog.Field{otlog.String("event", name)}, fields...)...)
+}
+
+// retries returns the number of requests retried by the http.Transport on a new connection.
+func (c *clientTrace) retries() int {
+	if n := atomic.LoadInt32(&c.getConns); n > 1 {
+		return int(n - 1)
+	}
+
+	return 0
+}
+
+func (c *clientTrace) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(hostPort string) {
+			atomic.AddInt32(&c.getConns, 1)
+
+			c.event("get_conn", otlog.String("host_port", hostPort))
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			c.event("got_conn", otlog.Bool("reused", info.Reused), otlog.Bool("was_idle", info.WasIdle))
+		},
+		DNSStart: func(info httptrace.DNSStartInfo) {
+			c.event("dns_start", otlog.String("host", info.Host))
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			if info.Err != nil {
+				c.event("dns_done", otlog.Error(info.Err))
+
+				return
+			}
+
+			c.event("dns_done")
+		},
+		ConnectStart: func(network, addr string) {
+			c.event("connect_start", otlog.String("network", network), otlog.String("addr", addr))
+		},
+		ConnectDone: func(network, addr string, err error) {
+			if err != nil {
+				c.event("connect_done", otlog.String("addr", addr), otlog.Error(err))
+
+				return
+			}
+
+			c.event("connect_done", otlog.String("addr", addr))
+		},
+		TLSHandshakeStart: func() {
+			c.event("tls_handshake_start")
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err != nil {
+				c.event("tls_handshake_done", otlog.Error(err))
+
+				return
+			}
+
+			c.event("tls_handshake_done", otlog.String("version", tls.VersionName(state.Version)))
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err != nil {
+				c.event("wrote_request", otlog.Error(info.Err))
+
+				return
+			}
+
+			c.event("wrote_request")
+		},
+		GotFirstResponseByte: func() {
+			c.event("got_first_response_byte")
+		},
+	}
+}
+
+// tracedBody finishes the span when the response body is closed.
+type tracedBody struct {
+	io.ReadCloser
+	span opentracing.Span
+	once sync.Once
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		ext.LogError(b.span, err)
+	}
+
+	return n, err // nolint: wrapcheck
+}
+
+func (b *tracedBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	b.once.Do(b.span.Finish)
+
+	return err // nolint: wrapcheck
+}
+
+// tracedReadWriteBody preserves the io.Writer of the 101 Switching Protocols responses.
+type tracedReadWriteBody struct {
+	*tracedBody
+	io.Writer
+}
+
+func wrapBody(body io.ReadCloser, span opentracing.Span) io.ReadCloser {
+	if body == nil {
+		span.Finish()
+
+		return body
+	}
+
+	tb := &tracedBody{
+		ReadCloser: body,
+		span:       span,
+	}
+
+	if w, ok := body.(io.Writer); ok {
+		return &tracedReadWriteBody{
+			tracedBody: tb,
+			Writer:     w,
+		}
+	}
+
+	return tb
+}
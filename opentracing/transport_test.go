@@ -0,0 +1,182 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package opentracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransport(t *testing.T) {
+	tracer := mocktracer.New()
+
+	var traceID string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID = r.Header.Get("Mockpfx-Ids-Traceid")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	parent := tracer.StartSpan("parent")
+
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+	ctx = WithAttempt(ctx, 2)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/foo?bar=baz", nil)
+	assert.NoError(t, err)
+
+	client := &http.Client{
+		Transport: NewTransport(tracer, nil),
+	}
+
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+
+	assert.Empty(t, req.Header.Get("Mockpfx-Ids-Traceid"), "the original request must not be modified")
+	assert.Empty(t, tracer.FinishedSpans(), "the span is finished when the body is closed")
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	assert.NoError(t, resp.Body.Close())
+	assert.NoError(t, resp.Body.Close())
+
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 1)
+
+	span := spans[0]
+	parentCtx := parent.Context().(mocktracer.MockSpanContext) // nolint: forcetypeassert
+
+	assert.Equal(t, "HTTP GET", span.OperationName)
+	assert.Equal(t, parentCtx.SpanID, span.ParentID)
+	assert.NotEmpty(t, traceID)
+	assert.Equal(t, ext.SpanKindRPCClientEnum, span.Tag("span.kind"))
+	assert.Equal(t, "net/http", span.Tag("component"))
+	assert.Equal(t, "GET", span.Tag("http.method"))
+	assert.Equal(t, ts.URL+"/foo", span.Tag("http.url"))
+	assert.Equal(t, "127.0.0.1", span.Tag("peer.hostname"))
+	assert.NotNil(t, span.Tag("peer.port"))
+	assert.Equal(t, uint16(200), span.Tag("http.status_code"))
+	assert.Equal(t, 2, span.Tag("http.attempt"))
+	assert.Nil(t, span.Tag("error"))
+
+	events := make(map[string]bool)
+
+	for _, record := range span.Logs() {
+		for _, field := range record.Fields {
+			if field.Key == "event" {
+				events[field.ValueString] = true
+			}
+		}
+	}
+
+	assert.True(t, events["get_conn"])
+	assert.True(t, events["connect_done"])
+	assert.True(t, events["wrote_request"])
+	assert.True(t, events["got_first_response_byte"])
+}
+
+func TestTransportWithServerError(t *testing.T) {
+	tracer := mocktracer.New()
+
+	transport := &Transport{
+		Tracer: tracer,
+		Base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(http.NoBody),
+			}, nil
+		}),
+		OperationName: func(req *http.Request) string {
+			return "upstream"
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/foo", nil)
+
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 1)
+
+	assert.Equal(t, "upstream", spans[0].OperationName)
+	assert.Equal(t, 0, spans[0].ParentID)
+	assert.Equal(t, "example.com", spans[0].Tag("peer.hostname"))
+	assert.Equal(t, uint16(502), spans[0].Tag("http.status_code"))
+	assert.Equal(t, true, spans[0].Tag("error"))
+}
+
+func TestTransportWithError(t *testing.T) {
+	tracer := mocktracer.New()
+
+	transport := NewTransport(tracer, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+
+	resp, err := transport.RoundTrip(req) // nolint: bodyclose
+	assert.EqualError(t, err, "connection refused")
+	assert.Nil(t, resp)
+
+	spans := tracer.FinishedSpans()
+	assert.Len(t, spans, 1)
+
+	assert.Equal(t, true, spans[0].Tag("error"))
+	assert.NotEmpty(t, spans[0].Logs())
+}
+
+func TestTransportPreservesWriterBody(t *testing.T) {
+	tracer := mocktracer.New()
+
+	type readWriteCloser struct {
+		io.Reader
+		io.Writer
+		io.Closer
+	}
+
+	transport := NewTransport(tracer, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusSwitchingProtocols,
+			Body: readWriteCloser{
+				Reader: http.NoBody,
+				Writer: io.Discard,
+				Closer: io.NopCloser(nil),
+			},
+		}, nil
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/ws", nil)
+
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+
+	_, ok := resp.Body.(io.Writer)
+	assert.True(t, ok)
+
+	assert.NoError(t, resp.Body.Close())
+	assert.Len(t, tracer.FinishedSpans(), 1)
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package otel
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/euskadi31/go-server/internal/route"
+	"github.com/rs/zerolog/log"
+	"github.com/zenazn/goji/web/mutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIgnorerFunc is the type of a function for use in Configuration.Ignore.
+type RequestIgnorerFunc func(*http.Request) bool
+
+// IgnoreNone is a RequestIgnorerFunc which ignores no requests.
+func IgnoreNone(*http.Request) bool {
+	return false
+}
+
+// Configuration struct, the global providers are used when nil.
+type Configuration struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	// Propagator of the incoming context, DefaultPropagator is used when nil
+	// and no global propagator has been set.
+	Propagator propagation.TextMapPropagator
+	Ignore     RequestIgnorerFunc
+}
+
+// durationBuckets are the buckets advised by the semantic conventions for http.server.request.duration.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+type instruments struct {
+	duration       metric.Float64Histogram
+	activeRequests metric.Int64UpDownCounter
+	requestSize    metric.Int64Histogram
+	responseSize   metric.Int64Histogram
+}
+
+func newInstruments(meter metric.Meter) *instruments {
+	var err error
+
+	i := &instruments{}
+
+	if i.duration, err = meter.Float64Histogram(
+		"http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP server requests."),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	); err != nil {
+		log.Error().Err(err).Msg("otel create http.server.request.duration")
+	}
+
+	if i.activeRequests, err = meter.Int64UpDownCounter(
+		"http.server.active_requests",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of active HTTP server requests."),
+	); err != nil {
+		log.Error().Err(err).Msg("otel create http.server.active_requests")
+	}
+
+	if i.requestSize, err = meter.Int64Histogram(
+		"http.server.request.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of HTTP server request bodies."),
+	); err != nil {
+		log.Error().Err(err).Msg("otel create http.server.request.body.size")
+	}
+
+	if i.responseSize, err = meter.Int64Histogram(
+		"http.server.response.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of HTTP server response bodies."),
+	); err != nil {
+		log.Error().Err(err).Msg("otel create http.server.response.body.size")
+	}
+
+	return i
+}
+
+// Handler traces the requests with a server span named after the mux route template
+// and records the HTTP server metrics of the semantic conventions.
+func Handler(cfg *Configuration) func(next http.Handler) http.Handler {
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+
+	if cfg.Ignore == nil {
+		cfg.Ignore = IgnoreNone
+	}
+
+	tracer := cfg.TracerProvider.Tracer(ScopeName)
+	metrics := newInstruments(cfg.MeterProvider.Meter(ScopeName))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cfg.Ignore(r) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			ts := time.Now()
+
+			ctx := propagator(cfg.Propagator).Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			tpl, hasRoute := route.Template(r)
+
+			// The active requests are not labeled by route, see the semantic conventions.
+			activeAttrs := append(methodAttributes(r.Method), semconv.URLScheme(scheme(r)))
+
+			metricAttrs := make([]attribute.KeyValue, 0, len(activeAttrs)+4)
+			metricAttrs = append(metricAttrs, activeAttrs...)
+			metricAttrs = append(metricAttrs, semconv.NetworkProtocolVersion(protocolVersion(r)))
+
+			name := r.Method
+			if hasRoute {
+				name += " " + tpl
+
+				metricAttrs = append(metricAttrs, semconv.HTTPRoute(tpl))
+			}
+
+			ctx, span := tracer.Start(
+				ctx,
+				name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(metricAttrs...),
+				trace.WithAttributes(serverAttributes(r)...),
+			)
+			defer span.End()
+
+			active := metric.WithAttributeSet(attribute.NewSet(activeAttrs...))
+
+			metrics.activeRequests.Add(ctx, 1, active)
+			defer metrics.activeRequests.Add(ctx, -1, active)
+
+			lw := mutil.WrapWriter(w)
+
+			next.ServeHTTP(lw, r.WithContext(ctx))
+
+			status := lw.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+			if lw.BytesWritten() > 0 {
+				span.SetAttributes(semconv.HTTPResponseBodySize(lw.BytesWritten()))
+			}
+
+			metricAttrs = append(metricAttrs, semconv.HTTPResponseStatusCode(status))
+
+			// Only the server errors are span errors, see the semantic conventions.
+			if status >= http.StatusInternalServerError {
+				errorType := semconv.ErrorTypeKey.String(strconv.Itoa(status))
+
+				span.SetAttributes(errorType)
+				span.SetStatus(codes.Error, http.StatusText(status))
+
+				metricAttrs = append(metricAttrs, errorType)
+			}
+
+			set := metric.WithAttributeSet(attribute.NewSet(metricAttrs...))
+
+			metrics.duration.Record(ctx, time.Since(ts).Seconds(), set)
+			metrics.responseSize.Record(ctx, int64(lw.BytesWritten()), set)
+
+			if r.ContentLength >= 0 {
+				metrics.requestSize.Record(ctx, r.ContentLength, set)
+			}
+		})
+	}
+}
+
+// serverAttributes returns the span attributes describing the request.
+func serverAttributes(r *http.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.URLPath(r.URL.Path),
+	}
+
+	if r.URL.RawQuery != "" {
+		attrs = append(attrs, semconv.URLQuery(r.URL.RawQuery))
+	}
+
+	if host, port := hostPort(r.Host); host != "" {
+		attrs = append(attrs, semconv.ServerAddress(host))
+
+		if port > 0 {
+			attrs = append(attrs, semconv.ServerPort(port))
+		}
+	}
+
+	if host, port := hostPort(r.RemoteAddr); host != "" {
+		attrs = append(attrs, semconv.ClientAddress(host), semconv.NetworkPeerAddress(host))
+
+		if port > 0 {
+			attrs = append(attrs, semconv.NetworkPeerPort(port))
+		}
+	}
+
+	if ua := r.UserAgent(); ua != "" {
+		attrs = append(attrs, semconv.UserAgentOriginal(ua))
+	}
+
+	if r.ContentLength > 0 {
+		attrs = append(attrs, semconv.HTTPRequestBodySize(int(r.ContentLength)))
+	}
+
+	return attrs
+}
+
+func scheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+
+	if r.URL.Scheme != "" {
+		return r.URL.Scheme
+	}
+
+	return "http"
+}
@@ -0,0 +1,236 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type testProviders struct {
+	exporter *tracetest.InMemoryExporter
+	reader   *sdkmetric.ManualReader
+	cfg      *Configuration
+}
+
+func newTestProviders() *testProviders {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	return &testProviders{
+		exporter: exporter,
+		reader:   reader,
+		cfg: &Configuration{
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+			MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		},
+	}
+}
+
+func (p *testProviders) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	rm := metricdata.ResourceMetrics{}
+	assert.NoError(t, p.reader.Collect(context.Background(), &rm))
+
+	metrics := map[string]metricdata.Aggregation{}
+
+	for _, sm := range rm.ScopeMetrics {
+		assert.Equal(t, ScopeName, sm.Scope.Name)
+
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	return metrics
+}
+
+func attributes(kvs []attribute.KeyValue) map[string]string {
+	attrs := make(map[string]string, len(kvs))
+
+	for _, kv := range kvs {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return attrs
+}
+
+func TestHandlerOnRouter(t *testing.T) {
+	p := newTestProviders()
+
+	r := mux.NewRouter()
+	r.Use(Handler(p.cfg))
+	r.HandleFunc("/users/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, trace.SpanFromContext(r.Context()).SpanContext().IsValid())
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("created"))
+	}).Methods(http.MethodPost)
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com:8080/users/42?foo=bar", strings.NewReader("hello"))
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("User-Agent", "test/1.0")
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := p.exporter.GetSpans()
+	assert.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "POST /users/{id}", span.Name)
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+	assert.Equal(t, codes.Unset, span.Status.Code)
+	assert.False(t, span.Parent.IsValid())
+
+	attrs := attributes(span.Attributes)
+	assert.Equal(t, "POST", attrs["http.request.method"])
+	assert.Equal(t, "/users/{id}", attrs["http.route"])
+	assert.Equal(t, "201", attrs["http.response.status_code"])
+	assert.Equal(t, "/users/42", attrs["url.path"])
+	assert.Equal(t, "foo=bar", attrs["url.query"])
+	assert.Equal(t, "http", attrs["url.scheme"])
+	assert.Equal(t, "example.com", attrs["server.address"])
+	assert.Equal(t, "8080", attrs["server.port"])
+	assert.Equal(t, "192.0.2.1", attrs["client.address"])
+	assert.Equal(t, "1234", attrs["network.peer.port"])
+	assert.Equal(t, "test/1.0", attrs["user_agent.original"])
+	assert.Equal(t, "1.1", attrs["network.protocol.version"])
+	assert.Equal(t, "5", attrs["http.request.body.size"])
+	assert.Equal(t, "7", attrs["http.response.body.size"])
+
+	metrics := p.metrics(t)
+
+	duration, ok := metrics["http.server.request.duration"].(metricdata.Histogram[float64])
+	assert.True(t, ok)
+	assert.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+
+	route, ok := duration.DataPoints[0].Attributes.Value("http.route")
+	assert.True(t, ok)
+	assert.Equal(t, "/users/{id}", route.AsString())
+
+	status, ok := duration.DataPoints[0].Attributes.Value("http.response.status_code")
+	assert.True(t, ok)
+	assert.Equal(t, int64(201), status.AsInt64())
+
+	active, ok := metrics["http.server.active_requests"].(metricdata.Sum[int64])
+	assert.True(t, ok)
+	assert.Len(t, active.DataPoints, 1)
+	assert.Equal(t, int64(0), active.DataPoints[0].Value)
+
+	_, ok = active.DataPoints[0].Attributes.Value("http.route")
+	assert.False(t, ok)
+
+	requestSize, ok := metrics["http.server.request.body.size"].(metricdata.Histogram[int64])
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), requestSize.DataPoints[0].Sum)
+
+	responseSize, ok := metrics["http.server.response.body.size"].(metricdata.Histogram[int64])
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), responseSize.DataPoints[0].Sum)
+}
+
+func TestHandlerPropagation(t *testing.T) {
+	p := newTestProviders()
+
+	handler := Handler(p.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "alice", baggage.FromContext(r.Context()).Member("user").Value())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("baggage", "user=alice")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := p.exporter.GetSpans()
+	assert.Len(t, spans, 1)
+
+	// Without route, the span is named after the method to bound the cardinality.
+	assert.Equal(t, http.MethodGet, spans[0].Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	assert.True(t, spans[0].Parent.IsRemote())
+}
+
+func TestHandlerWithServerError(t *testing.T) {
+	p := newTestProviders()
+
+	handler := Handler(p.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	req := httptest.NewRequest("PURGE", "https://example.com/foo", nil)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := p.exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+	attrs := attributes(spans[0].Attributes)
+	assert.Equal(t, "502", attrs["error.type"])
+	assert.Equal(t, "_OTHER", attrs["http.request.method"])
+	assert.Equal(t, "PURGE", attrs["http.request.method_original"])
+	assert.Equal(t, "https", attrs["url.scheme"])
+}
+
+func TestHandlerWithClientError(t *testing.T) {
+	p := newTestProviders()
+
+	handler := Handler(p.cfg)(http.NotFoundHandler())
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	spans := p.exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.NotContains(t, attributes(spans[0].Attributes), "error.type")
+}
+
+func TestHandlerWithIgnore(t *testing.T) {
+	p := newTestProviders()
+	p.cfg.Ignore = func(*http.Request) bool {
+		return true
+	}
+
+	handler := Handler(p.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, trace.SpanFromContext(r.Context()).SpanContext().IsValid())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	assert.Empty(t, p.exporter.GetSpans())
+}
+
+func TestIgnoreNone(t *testing.T) {
+	assert.False(t, IgnoreNone(httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)))
+}
+
+func TestProtocolVersion(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	assert.Equal(t, "1.1", protocolVersion(req))
+
+	req.ProtoMajor, req.ProtoMinor = 1, 0
+	assert.Equal(t, "1.0", protocolVersion(req))
+
+	req.ProtoMajor, req.ProtoMinor = 2, 0
+	assert.Equal(t, "2", protocolVersion(req))
+}
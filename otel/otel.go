@@ -0,0 +1,77 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package otel instruments the HTTP servers and clients with OpenTelemetry,
+// it is the successor of the opentracing package.
+package otel
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+// ScopeName is the instrumentation scope of the tracer and the meter.
+const ScopeName = "github.com/euskadi31/go-server/otel"
+
+// DefaultPropagator propagates the W3C Trace Context and Baggage, it is used
+// when no global propagator has been set with otel.SetTextMapPropagator.
+var DefaultPropagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// propagator returns the global propagator, or DefaultPropagator if it is a noop.
+func propagator(p propagation.TextMapPropagator) propagation.TextMapPropagator {
+	if p != nil {
+		return p
+	}
+
+	if global := otel.GetTextMapPropagator(); len(global.Fields()) > 0 {
+		return global
+	}
+
+	return DefaultPropagator
+}
+
+// methodAttributes returns the http.request.method attribute, the non standard
+// methods are reported as _OTHER with the original method.
+func methodAttributes(method string) []attribute.KeyValue {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(method)}
+	default:
+		return []attribute.KeyValue{semconv.HTTPRequestMethodOther, semconv.HTTPRequestMethodOriginal(method)}
+	}
+}
+
+// hostPort splits an address with an optional port, the port is 0 when missing.
+func hostPort(addr string) (string, int) {
+	host, portString, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, 0
+	}
+
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return host, 0
+	}
+
+	return host, port
+}
+
+// protocolVersion returns the network.protocol.version of the request ("1.1", "2").
+func protocolVersion(r *http.Request) string {
+	if r.ProtoMajor >= 2 {
+		return strconv.Itoa(r.ProtoMajor)
+	}
+
+	return strconv.Itoa(r.ProtoMajor) + "." + strconv.Itoa(r.ProtoMinor)
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package otel
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// WrapRequest injects the span context and the baggage found in ctx into the HTTP headers
+// with the global propagator, or DefaultPropagator if none has been set.
+// If ctx does not carry a span nor baggage, WrapRequest is a noop.
+func WrapRequest(ctx context.Context, req *http.Request) *http.Request {
+	propagator(nil).Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req
+}
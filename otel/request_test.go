@@ -0,0 +1,43 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package otel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWrapRequest(t *testing.T) {
+	p := newTestProviders()
+
+	handler := Handler(p.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subReq := httptest.NewRequest(http.MethodGet, "http://example.org/bar", nil)
+		subReq2 := WrapRequest(r.Context(), subReq)
+
+		assert.Equal(t, subReq, subReq2)
+
+		sc := trace.SpanFromContext(r.Context()).SpanContext()
+
+		assert.Equal(t, "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01", subReq2.Header.Get("traceparent"))
+		assert.Equal(t, "user=alice", subReq2.Header.Get("baggage"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("baggage", "user=alice")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestWrapRequestWithoutSpan(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	r2 := WrapRequest(r.Context(), r)
+
+	assert.Equal(t, r, r2)
+	assert.Empty(t, r2.Header.Get("traceparent"))
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/euskadi31/go-server/internal/reqinfo"
+	"github.com/euskadi31/go-server/response"
+	"github.com/opentracing/opentracing-go"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultHeader is the header containing the request ID.
+const DefaultHeader = response.RequestIDHeader
+
+// MaxLength of a request ID received from the client, longer IDs are replaced.
+const MaxLength = 128
+
+type key int
+
+const (
+	contextKey key = iota
+)
+
+// GeneratorFunc returns a new request ID.
+type GeneratorFunc func() string
+
+// Configuration struct.
+type Configuration struct {
+	// Header containing the request ID, DefaultHeader is used when empty.
+	Header string
+	// Generator of the request IDs, a random 128 bits hex string is used when nil.
+	Generator GeneratorFunc
+}
+
+// ToContext adds the request ID to the context.
+func ToContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey, id)
+}
+
+// FromContext returns the request ID of the context.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey).(string)
+
+	return id, ok
+}
+
+// Handler reads the request ID from the request header or the trace ID of the W3C traceparent
+// header, or generates a new one. The request ID is added to the context, echoed in the response
+// header, added to the logger of the context (see zerolog.Ctx) and tagged on the current span.
+func Handler(cfg *Configuration) func(next http.Handler) http.Handler {
+	if cfg.Header == "" {
+		cfg.Header = DefaultHeader
+	}
+
+	if cfg.Generator == nil {
+		cfg.Generator = Generate
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(cfg.Header)
+			if !valid(id) {
+				id = ""
+			}
+
+			if id == "" {
+				id = traceIDFromTraceParent(r.Header.Get("traceparent"))
+			}
+
+			if id == "" {
+				id = cfg.Generator()
+			}
+
+			r.Header.Set(cfg.Header, id)
+			w.Header().Set(cfg.Header, id)
+
+			// The response package reads the request ID from this header.
+			if cfg.Header != response.RequestIDHeader {
+				w.Header().Set(response.RequestIDHeader, id)
+			}
+
+			reqinfo.SetRequestID(r.Context(), id)
+
+			ctx := ToContext(r.Context(), id)
+
+			parent := zerolog.Ctx(ctx)
+			if parent.GetLevel() == zerolog.Disabled {
+				parent = &log.Logger
+			}
+
+			logger := parent.With().Str("request_id", id).Logger()
+
+			ctx = logger.WithContext(ctx)
+
+			if span := opentracing.SpanFromContext(ctx); span != nil {
+				span.SetTag("request_id", id)
+			}
+
+			if span := trace.SpanFromContext(ctx); span.IsRecording() {
+				span.SetAttributes(attribute.String("request_id", id))
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Generate returns a random 128 bits hex encoded request ID.
+func Generate() string {
+	b := make([]byte, 16)
+
+	// crypto/rand.Read never returns an error.
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// valid reports whether the request ID received from the client can be safely
+// used in the headers and the logs.
+func valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// traceIDFromTraceParent returns the trace ID of a W3C traceparent header
+// ("00-{trace-id}-{parent-id}-{flags}").
+func traceIDFromTraceParent(value string) string {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 {
+		return ""
+	}
+
+	traceID := parts[1]
+
+	if _, err := hex.DecodeString(traceID); err != nil || traceID == strings.Repeat("0", 32) {
+		return ""
+	}
+
+	return strings.ToLower(traceID)
+}
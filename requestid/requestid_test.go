@@ -0,0 +1,140 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/euskadi31/go-server/response"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerWithRequestIDHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("X-Request-ID", "abc-123")
+
+	w := httptest.NewRecorder()
+
+	Handler(&Configuration{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := FromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "abc-123", id)
+
+		response.InternalServerFailure(w)
+	})).ServeHTTP(w, req)
+
+	assert.Equal(t, "abc-123", w.Header().Get("X-Request-ID"))
+	assert.JSONEq(t, `{"error":{"code":500,"message":"Internal Server Error"},"request_id":"abc-123"}`, w.Body.String())
+}
+
+func TestHandlerWithTraceParent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	w := httptest.NewRecorder()
+
+	Handler(&Configuration{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := FromContext(r.Context())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", id)
+	})).ServeHTTP(w, req)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get("X-Request-ID"))
+}
+
+func TestHandlerGeneratesRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("X-Request-ID", "bad id\n")
+
+	w := httptest.NewRecorder()
+
+	Handler(&Configuration{
+		Generator: func() string {
+			return "generated"
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := FromContext(r.Context())
+		assert.Equal(t, "generated", id)
+		assert.Equal(t, "generated", r.Header.Get("X-Request-ID"))
+	})).ServeHTTP(w, req)
+
+	assert.Equal(t, "generated", w.Header().Get("X-Request-ID"))
+}
+
+func TestHandlerWithCustomHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("X-Correlation-ID", "corr-1")
+
+	w := httptest.NewRecorder()
+
+	Handler(&Configuration{
+		Header: "X-Correlation-ID",
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.FailureFromError(w, http.StatusBadRequest, assert.AnError)
+	})).ServeHTTP(w, req)
+
+	assert.Equal(t, "corr-1", w.Header().Get("X-Correlation-ID"))
+	assert.Contains(t, w.Body.String(), `"request_id":"corr-1"`)
+}
+
+func TestHandlerLoggerAndSpan(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("GET /foo")
+
+	ctx := logger.WithContext(context.Background())
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil).WithContext(ctx)
+	req.Header.Set("X-Request-ID", "abc-123")
+
+	w := httptest.NewRecorder()
+
+	Handler(&Configuration{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		zerolog.Ctx(r.Context()).Info().Msg("hello")
+	})).ServeHTTP(w, req)
+
+	span.Finish()
+
+	assert.JSONEq(t, `{"level":"info","request_id":"abc-123","message":"hello"}`, buf.String())
+	assert.Equal(t, "abc-123", tracer.FinishedSpans()[0].Tag("request_id"))
+}
+
+func TestFromContextWithoutRequestID(t *testing.T) {
+	id, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	assert.Empty(t, id)
+}
+
+func TestGenerate(t *testing.T) {
+	id := Generate()
+
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, Generate())
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, valid("abc-123"))
+	assert.False(t, valid(""))
+	assert.False(t, valid("a b"))
+	assert.False(t, valid(strings.Repeat("a", MaxLength+1)))
+}
+
+func TestTraceIDFromTraceParent(t *testing.T) {
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceIDFromTraceParent("00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"))
+	assert.Empty(t, traceIDFromTraceParent(""))
+	assert.Empty(t, traceIDFromTraceParent("00-00000000000000000000000000000000-00f067aa0ba902b7-01"))
+	assert.Empty(t, traceIDFromTraceParent("ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+	assert.Empty(t, traceIDFromTraceParent("00-zzz-00f067aa0ba902b7-01"))
+}
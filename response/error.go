@@ -48,10 +48,12 @@ func (e ValidatorError) Error() string {
 
 // ErrorResponse struct.
 type ErrorResponse struct {
-	Error ErrorMessage `json:"error"`
+	Error     ErrorMessage `json:"error"`
+	RequestID string       `json:"request_id,omitempty"`
 }
 
 // ErrorsResponse struct.
 type ErrorsResponse struct {
-	Errors []error `json:"errors"`
+	Errors    []error `json:"errors"`
+	RequestID string  `json:"request_id,omitempty"`
 }
@@ -18,6 +18,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// RequestIDHeader is the response header echoing the request ID, set by the requestid middleware
+// and included in the body of the error responses.
+const RequestIDHeader = "X-Request-ID"
+
 // StatusCode returns the HTTP response status.
 // Remember that the status is only set by the server after WriteHeader has been called.
 func StatusCode(w http.ResponseWriter) int {
@@ -86,7 +90,8 @@ func Failure(w http.ResponseWriter, status int, err ErrorMessage) {
 	w.WriteHeader(status)
 
 	body := ErrorResponse{
-		Error: err,
+		Error:     err,
+		RequestID: w.Header().Get(RequestIDHeader),
 	}
 
 	if err := json.NewEncoder(w).Encode(body); err != nil {
@@ -100,7 +105,9 @@ func FailureFromValidator(w http.ResponseWriter, result *validate.Result) {
 	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.WriteHeader(http.StatusBadRequest)
 
-	body := ErrorsResponse{}
+	body := ErrorsResponse{
+		RequestID: w.Header().Get(RequestIDHeader),
+	}
 
 	for _, err := range result.Errors {
 		var item error
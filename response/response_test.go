@@ -59,6 +59,18 @@ func TestFailure(t *testing.T) {
 	assert.JSONEq(t, `{"error":{"code":1337,"message":"user_message"}}`, w.Body.String())
 }
 
+func TestFailureWithRequestID(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set(RequestIDHeader, "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	Failure(w, http.StatusInternalServerError, ErrorMessage{
+		Code:    1337,
+		Message: "user_message",
+	})
+
+	assert.JSONEq(t, `{"error":{"code":1337,"message":"user_message"},"request_id":"4bf92f3577b34da6a3ce929d0e0e4736"}`, w.Body.String())
+}
+
 func TestFailureFailed(t *testing.T) {
 	w := &mockResponseWriter{}
 
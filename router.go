@@ -5,11 +5,19 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/euskadi31/go-server/accesslog"
+	"github.com/euskadi31/go-server/authentication"
+	"github.com/euskadi31/go-server/logging"
 	"github.com/euskadi31/go-server/metrics"
+	"github.com/euskadi31/go-server/requestid"
 	"github.com/euskadi31/go-server/response"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
@@ -17,55 +25,349 @@ import (
 	"github.com/rs/cors"
 )
 
+// healthCheckVersion of the detailed health check responses.
+type healthCheckVersion struct {
+	version   string
+	releaseID string
+}
+
 // Router struct.
 type Router struct {
 	*mux.Router
-	healthchecks map[string]HealthCheckHandler
+	healthchecksMtx sync.RWMutex
+	healthchecks    map[string]*healthCheck
+	healthCache     atomic.Pointer[healthCheckCache]
+	healthObserver  healthCheckObserver
+	warmingUp       atomic.Bool
+	shuttingDown    atomic.Bool
+	version         atomic.Pointer[healthCheckVersion]
+	started         time.Time
 }
 
 // NewRouter constructor.
 func NewRouter() *Router {
 	return &Router{
 		Router:       mux.NewRouter(),
-		healthchecks: make(map[string]HealthCheckHandler),
+		healthchecks: make(map[string]*healthCheck),
+		started:      time.Now(),
 	}
 }
 
 // AddHealthCheck handler.
 func (r *Router) AddHealthCheck(name string, handle HealthCheckHandler) error {
+	return r.AddHealthChecker(name, legacyHealthCheck(handle), HealthCheckOptions{})
+}
+
+// AddHealthChecker adds a health check returning a detailed result.
+func (r *Router) AddHealthChecker(name string, checker HealthChecker, opts HealthCheckOptions) error {
+	r.healthchecksMtx.Lock()
+	defer r.healthchecksMtx.Unlock()
+
 	if _, ok := r.healthchecks[name]; ok {
 		return fmt.Errorf("the %s healthcheck handler already exists", name)
 	}
 
-	r.healthchecks[name] = handle
+	check := newHealthCheck(checker, opts)
+
+	r.healthchecks[name] = check
+
+	r.evaluateHealthCheck(name, check)
+
+	return nil
+}
+
+// ReplaceHealthCheck replaces the handler of an existing health check.
+func (r *Router) ReplaceHealthCheck(name string, handle HealthCheckHandler) error {
+	return r.ReplaceHealthChecker(name, legacyHealthCheck(handle), HealthCheckOptions{})
+}
+
+// ReplaceHealthChecker replaces an existing health check, the running evaluations
+// complete with the previous check.
+func (r *Router) ReplaceHealthChecker(name string, checker HealthChecker, opts HealthCheckOptions) error {
+	r.healthchecksMtx.Lock()
+	defer r.healthchecksMtx.Unlock()
+
+	prev, ok := r.healthchecks[name]
+	if !ok {
+		return fmt.Errorf("the %s healthcheck handler does not exist", name)
+	}
+
+	prev.removed.Store(true)
+
+	check := newHealthCheck(checker, opts)
+
+	r.healthchecks[name] = check
+
+	r.evaluateHealthCheck(name, check)
 
 	return nil
 }
 
-// EnableHealthCheck endpoint.
+// RemoveHealthCheck removes a health check, its status is forgotten and its metrics are deleted.
+func (r *Router) RemoveHealthCheck(name string) error {
+	r.healthchecksMtx.Lock()
+	defer r.healthchecksMtx.Unlock()
+
+	check, ok := r.healthchecks[name]
+	if !ok {
+		return fmt.Errorf("the %s healthcheck handler does not exist", name)
+	}
+
+	check.removed.Store(true)
+
+	delete(r.healthchecks, name)
+
+	if cache := r.healthCache.Load(); cache != nil {
+		cache.forget(name)
+	}
+
+	r.healthObserver.forget(name)
+
+	return nil
+}
+
+// EnableBackgroundHealthCheck runs the health checks every interval in background,
+// the health check endpoints serve the cached results instead of running the checks.
+// The checks registered later are evaluated right away, a check without result yet is failing.
+// The interval must be positive.
+func (r *Router) EnableBackgroundHealthCheck(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("the health check interval must be positive: %s", interval)
+	}
+
+	cache := newHealthCheckCache()
+
+	if prev := r.healthCache.Swap(cache); prev != nil {
+		prev.close()
+	}
+
+	go cache.loop(interval, func() map[string]*healthCheck {
+		return r.healthChecksOf("")
+	}, r.runHealthChecks)
+
+	return nil
+}
+
+// evaluateHealthCheck caches the result of a check registered while the background
+// evaluation runs, it does not wait for the next tick.
+func (r *Router) evaluateHealthCheck(name string, check *healthCheck) {
+	cache := r.healthCache.Load()
+	if cache == nil {
+		return
+	}
+
+	go cache.evaluate(map[string]*healthCheck{
+		name: check,
+	}, r.runHealthChecks)
+}
+
+// DisableBackgroundHealthCheck stops the background evaluation, the endpoints run the checks again.
+func (r *Router) DisableBackgroundHealthCheck() {
+	if cache := r.healthCache.Swap(nil); cache != nil {
+		cache.close()
+	}
+}
+
+// SubscribeHealthCheck calls fn on each status transition of a health check, the checks are
+// evaluated by the health check endpoints or in background. The returned function unsubscribes.
+func (r *Router) SubscribeHealthCheck(fn HealthCheckSubscriber) (unsubscribe func()) {
+	return r.healthObserver.subscribe(fn)
+}
+
+// SetWarmingUp sets the warm-up state, the startup probe fails while warming up.
+func (r *Router) SetWarmingUp(warmingUp bool) {
+	r.warmingUp.Store(warmingUp)
+}
+
+// SetShuttingDown makes the readiness probe fail, it is called when Server.Shutdown starts.
+func (r *Router) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// SetHealthCheckVersion sets the version and the release id of the detailed health check responses.
+func (r *Router) SetHealthCheckVersion(version string, releaseID string) {
+	r.version.Store(&healthCheckVersion{
+		version:   version,
+		releaseID: releaseID,
+	})
+}
+
+// runHealthChecks runs the checks and notifies the observer of the results.
+func (r *Router) runHealthChecks(ctx context.Context, healthchecks map[string]*healthCheck) HealthCheckResponse {
+	resp := healthCheckProcessor(ctx, healthchecks)
+
+	for name, results := range resp.Checks {
+		r.healthObserver.observe(name, healthchecks[name], results[0])
+	}
+
+	return resp
+}
+
+// EnableHealthCheck endpoints, the responses follow the Health Check Response Format
+// for HTTP APIs (application/health+json):
+//
+//	/health          all the checks
+//	/health/live     the liveness group
+//	/health/ready    the readiness group, failing once the shutdown started
+//	/health/startup  the startup group, failing until the warm-up is completed
+//	/health/details  all the checks with their details, the version and the uptime
+//
+// The public endpoints only expose the overall status. The details require an authenticated
+// principal (see authentication.Handler), use HealthCheckDetailsHandler to serve them on an
+// admin listener instead.
 func (r *Router) EnableHealthCheck() {
-	r.HandleFunc("/health", r.healthHandler).Methods(http.MethodGet, http.MethodHead)
+	r.healthObserver.enableHealthCheck()
+
+	r.Handle("/health", r.HealthCheckHandler("")).Methods(http.MethodGet, http.MethodHead)
+	r.Handle("/health/live", r.HealthCheckHandler(HealthGroupLiveness)).Methods(http.MethodGet, http.MethodHead)
+	r.Handle("/health/ready", r.HealthCheckHandler(HealthGroupReadiness)).Methods(http.MethodGet, http.MethodHead)
+	r.Handle("/health/startup", r.HealthCheckHandler(HealthGroupStartup)).Methods(http.MethodGet, http.MethodHead)
+	r.Handle("/health/details", requireAuthentication(r.HealthCheckDetailsHandler(""))).Methods(http.MethodGet, http.MethodHead)
+}
+
+// HealthCheckHandler returns the handler running the checks of the group, or all the checks
+// when the group is empty. The response only contains the overall status.
+func (r *Router) HealthCheckHandler(group string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeHealthCheckResponse(w, r.healthCheck(req.Context(), group).Terse())
+	})
 }
 
-func (r *Router) healthHandler(w http.ResponseWriter, req *http.Request) {
-	code := http.StatusOK
+// HealthCheckDetailsHandler returns the handler running the checks of the group with the result
+// of each check, the version and the uptime. The handler is not protected, it must be served
+// behind an authentication or on an admin listener.
+func (r *Router) HealthCheckDetailsHandler(group string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		resp := r.healthCheck(req.Context(), group)
+
+		if version := r.version.Load(); version != nil {
+			resp.Version = version.version
+			resp.ReleaseID = version.releaseID
+		}
+
+		resp.addCheck("uptime", HealthCheckResult{
+			Status:        HealthStatusPass,
+			ObservedValue: time.Since(r.started).Seconds(),
+			ObservedUnit:  "s",
+		}, HealthStatusPass)
+
+		writeHealthCheckResponse(w, resp)
+	})
+}
+
+// requireAuthentication rejects the requests without an authenticated principal.
+func requireAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authentication.FromContext(r.Context()); !ok {
+			response.Failure(w, http.StatusUnauthorized, response.ErrorMessage{
+				Code:    http.StatusUnauthorized,
+				Message: "Authentication required",
+			})
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// healthChecksOf returns the checks of the group.
+func (r *Router) healthChecksOf(group string) map[string]*healthCheck {
+	r.healthchecksMtx.RLock()
+	defer r.healthchecksMtx.RUnlock()
+
+	healthchecks := make(map[string]*healthCheck, len(r.healthchecks))
+
+	for name, check := range r.healthchecks {
+		if check.inGroup(group) {
+			healthchecks[name] = check
+		}
+	}
+
+	return healthchecks
+}
+
+func (r *Router) healthCheck(ctx context.Context, group string) HealthCheckResponse {
+	healthchecks := r.healthChecksOf(group)
+
+	var resp HealthCheckResponse
 
-	resp := healthCheckProcessor(r.healthchecks)
+	if cache := r.healthCache.Load(); cache != nil {
+		resp = cache.response(healthchecks)
+	} else {
+		resp = r.runHealthChecks(ctx, healthchecks)
+	}
 
-	if !resp.Status {
-		code = http.StatusServiceUnavailable
+	switch {
+	case group == HealthGroupReadiness && r.shuttingDown.Load():
+		resp.addCheck("shutdown", HealthCheckResult{
+			Status: HealthStatusFail,
+			Output: "The server is shutting down",
+		}, HealthStatusFail)
+	case group == HealthGroupStartup && r.warmingUp.Load():
+		resp.addCheck("warmup", HealthCheckResult{
+			Status: HealthStatusFail,
+			Output: "The server is warming up",
+		}, HealthStatusFail)
 	}
 
-	response.Encode(w, req, code, resp)
+	return resp
 }
 
 // EnableMetrics endpoint.
 func (r *Router) EnableMetrics() {
 	r.Use(metrics.Handler())
 
+	r.healthObserver.enableMetrics(metrics.Options{})
+
 	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
 }
 
+// EnableMetricsWithOptions endpoint, the metrics are registered in opts.Registerer
+// and the endpoint serves opts.Gatherer.
+func (r *Router) EnableMetricsWithOptions(opts metrics.Options) error {
+	handler, err := metrics.HandlerWithOptions(opts)
+	if err != nil {
+		return err // nolint: wrapcheck
+	}
+
+	r.Use(handler)
+
+	r.healthObserver.enableMetrics(opts)
+
+	r.Handle("/metrics", metrics.Exporter(opts)).Methods(http.MethodGet)
+
+	return nil
+}
+
+// EnableBuildInfo endpoint (/info) and build_info gauge, the build info is read from
+// runtime/debug.ReadBuildInfo. The version and the revision are also used by the detailed
+// health check response unless SetHealthCheckVersion was called.
+func (r *Router) EnableBuildInfo(opts BuildInfoOptions) error {
+	info := ReadBuildInfo()
+	info.Fields = opts.Fields
+
+	gauge, err := newBuildInfoGauge(info, opts.Metrics)
+	if err != nil {
+		return err
+	}
+
+	if err := registerBuildInfoGauge(gauge, opts.Metrics); err != nil {
+		return err
+	}
+
+	r.version.CompareAndSwap(nil, &healthCheckVersion{
+		version:   info.Version,
+		releaseID: info.Revision,
+	})
+
+	r.HandleFunc("/info", func(w http.ResponseWriter, req *http.Request) {
+		response.Encode(w, req, http.StatusOK, info)
+	}).Methods(http.MethodGet)
+
+	return nil
+}
+
 // EnableCors for all endpoint.
 func (r *Router) EnableCors() {
 	r.EnableCorsWithOptions(cors.Options{
@@ -82,6 +384,22 @@ func (r *Router) EnableProxy() {
 	}())
 }
 
+// EnableRequestID for reading or generating the X-Request-ID of each request,
+// see requestid.Handler.
+func (r *Router) EnableRequestID() {
+	r.Use(requestid.Handler(&requestid.Configuration{}))
+}
+
+// EnableAccessLog for all endpoint, see accesslog.Handler.
+func (r *Router) EnableAccessLog(cfg *accesslog.Configuration) {
+	r.Use(accesslog.Handler(cfg))
+}
+
+// EnableRequestLogger for adding a request scoped logger to the context, see logging.Handler.
+func (r *Router) EnableRequestLogger(cfg *logging.Configuration) {
+	r.Use(logging.Handler(cfg))
+}
+
 // EnableProfiling with pprof.
 func (r *Router) EnableProfiling() {
 	r.HandleFunc("/debug/pprof", pprof.Index).Methods(http.MethodGet)
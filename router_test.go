@@ -5,14 +5,34 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
-
+	"time"
+
+	"github.com/euskadi31/go-server/accesslog"
+	"github.com/euskadi31/go-server/authentication"
+	"github.com/euskadi31/go-server/logging"
+	"github.com/euskadi31/go-server/metrics"
+	"github.com/euskadi31/go-server/requestid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
+func authenticated(req *http.Request) *http.Request {
+	return req.WithContext(authentication.ToContext(req.Context(), &authentication.Principal{
+		Subject: "admin",
+	}))
+}
+
 func TestRouterEnableMetrics(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "http://example.com/metrics", nil)
 	w := httptest.NewRecorder()
@@ -47,9 +67,183 @@ func TestRouterEnableHealthCheck(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	b, err := ioutil.ReadAll(w.Body)
+	assert.JSONEq(t, `{"status":"pass"}`, w.Body.String(), "the public response only exposes the status")
+}
+
+func TestRouterHealthCheckDetails(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+	router.SetHealthCheckVersion("1.2.0", "1.2.0-4bf92f3")
+
+	err := router.AddHealthCheck("redis", HealthCheckHandlerFunc(func() bool {
+		return true
+	}))
 	assert.NoError(t, err)
-	assert.Contains(t, string(b), "redis")
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NotContains(t, w.Body.String(), "redis")
+
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, authenticated(req))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, HealthCheckMediaType, w.Header().Get("Content-Type"))
+
+	var resp struct {
+		Status    HealthStatus                        `json:"status"`
+		Version   string                              `json:"version"`
+		ReleaseID string                              `json:"releaseId"`
+		Checks    map[string][]map[string]interface{} `json:"checks"`
+	}
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, HealthStatusPass, resp.Status)
+	assert.Equal(t, "1.2.0", resp.Version)
+	assert.Equal(t, "1.2.0-4bf92f3", resp.ReleaseID)
+	assert.Equal(t, "pass", resp.Checks["redis"][0]["status"])
+	assert.Equal(t, "s", resp.Checks["uptime"][0]["observedUnit"])
+	assert.IsType(t, float64(0), resp.Checks["uptime"][0]["observedValue"])
+}
+
+func TestRouterAddHealthChecker(t *testing.T) {
+	req := authenticated(httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil))
+	w := httptest.NewRecorder()
+
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.AddHealthChecker("disk", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckResult{
+			Status:        HealthStatusWarn,
+			Output:        "Low disk space",
+			ObservedValue: 92,
+			ObservedUnit:  "percent",
+		}
+	}), HealthCheckOptions{
+		Timeout: time.Second,
+	})
+	assert.NoError(t, err)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, HealthCheckMediaType, w.Header().Get("Content-Type"))
+
+	var resp struct {
+		Status HealthStatus                        `json:"status"`
+		Checks map[string][]map[string]interface{} `json:"checks"`
+	}
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, HealthStatusWarn, resp.Status)
+	assert.Equal(t, "Low disk space", resp.Checks["disk"][0]["output"])
+	assert.Equal(t, float64(92), resp.Checks["disk"][0]["observedValue"])
+}
+
+func TestRouterHealthCheckGroups(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	fail := HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckFail(errors.New("down"))
+	})
+
+	assert.NoError(t, router.AddHealthChecker("process", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckPass()
+	}), HealthCheckOptions{
+		Groups: []string{HealthGroupLiveness},
+	}))
+	assert.NoError(t, router.AddHealthChecker("database", fail, HealthCheckOptions{}))
+	assert.NoError(t, router.AddHealthChecker("migrations", fail, HealthCheckOptions{
+		Groups: []string{HealthGroupStartup},
+	}))
+
+	get := func(group string) (int, string) {
+		w := httptest.NewRecorder()
+
+		router.HealthCheckDetailsHandler(group).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil))
+
+		return w.Code, w.Body.String()
+	}
+
+	code, body := get("")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "process")
+	assert.Contains(t, body, "database")
+	assert.Contains(t, body, "migrations")
+
+	code, body = get(HealthGroupLiveness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, "process")
+	assert.NotContains(t, body, "database")
+
+	code, body = get(HealthGroupReadiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "database")
+	assert.NotContains(t, body, "migrations")
+
+	code, body = get(HealthGroupStartup)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "database")
+	assert.Contains(t, body, "migrations")
+	assert.NotContains(t, body, "process")
+}
+
+func TestRouterHealthCheckProbesState(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	get := func(path string) (int, string) {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil))
+
+		return w.Code, w.Body.String()
+	}
+
+	code, body := get("/health/startup")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"status":"pass"}`, body)
+
+	router.SetWarmingUp(true)
+
+	code, body = get("/health/startup")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.JSONEq(t, `{"status":"fail"}`, body)
+
+	w := httptest.NewRecorder()
+
+	router.HealthCheckDetailsHandler(HealthGroupStartup).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil))
+	assert.Contains(t, w.Body.String(), `"warmup":[{"status":"fail","output":"The server is warming up"}]`)
+
+	router.SetWarmingUp(false)
+
+	code, _ = get("/health/startup")
+	assert.Equal(t, http.StatusOK, code)
+
+	router.SetShuttingDown()
+
+	code, body = get("/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.JSONEq(t, `{"status":"fail"}`, body)
+
+	w = httptest.NewRecorder()
+
+	router.HealthCheckDetailsHandler(HealthGroupReadiness).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil))
+	assert.Contains(t, w.Body.String(), `"shutdown":[{"status":"fail","output":"The server is shutting down"}]`)
+
+	code, _ = get("/health/live")
+	assert.Equal(t, http.StatusOK, code)
 }
 
 func TestRouterHealthCheckFailed(t *testing.T) {
@@ -70,7 +264,146 @@ func TestRouterHealthCheckFailed(t *testing.T) {
 	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
 	b, err := ioutil.ReadAll(w.Body)
 	assert.NoError(t, err)
-	assert.Contains(t, string(b), "redis")
+	assert.JSONEq(t, `{"status":"fail"}`, string(b))
+}
+
+func TestRouterNonCriticalHealthCheck(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.AddHealthChecker("cache", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckFail(errors.New("connection refused"))
+	}), HealthCheckOptions{
+		NonCritical: true,
+	})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, authenticated(httptest.NewRequest(http.MethodGet, "http://example.com/health/details", nil)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	response := struct {
+		Status HealthStatus                        `json:"status"`
+		Checks map[string][]map[string]interface{} `json:"checks"`
+	}{}
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, HealthStatusWarn, response.Status)
+	assert.Equal(t, "fail", response.Checks["cache"][0]["status"])
+}
+
+func TestRouterReplaceHealthCheck(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.ReplaceHealthCheck("redis", HealthCheckHandlerFunc(func() bool {
+		return true
+	}))
+	assert.EqualError(t, err, "the redis healthcheck handler does not exist")
+
+	err = router.AddHealthCheck("redis", HealthCheckHandlerFunc(func() bool {
+		return false
+	}))
+	assert.NoError(t, err)
+
+	err = router.ReplaceHealthCheck("redis", HealthCheckHandlerFunc(func() bool {
+		return true
+	}))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	err = router.ReplaceHealthChecker("redis", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		return HealthCheckFail(errors.New("connection refused"))
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestRouterRemoveHealthCheck(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	err := router.RemoveHealthCheck("redis")
+	assert.EqualError(t, err, "the redis healthcheck handler does not exist")
+
+	err = router.AddHealthCheck("redis", HealthCheckHandlerFunc(func() bool {
+		return false
+	}))
+	assert.NoError(t, err)
+
+	err = router.RemoveHealthCheck("redis")
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "redis")
+
+	err = router.AddHealthCheck("redis", HealthCheckHandlerFunc(func() bool {
+		return true
+	}))
+	assert.NoError(t, err, "a removed health check can be added again")
+}
+
+func TestRouterHealthCheckConcurrentUpdates(t *testing.T) {
+	router := NewRouter()
+
+	router.EnableHealthCheck()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		name := fmt.Sprintf("check-%d", i)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				_ = router.AddHealthChecker(name, HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+					return HealthCheckPass()
+				}), HealthCheckOptions{})
+
+				_ = router.ReplaceHealthCheck(name, HealthCheckHandlerFunc(func() bool {
+					return true
+				}))
+
+				_ = router.RemoveHealthCheck(name)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/health", nil))
+
+				assert.Equal(t, http.StatusOK, w.Code)
+			}
+		}()
+	}
+
+	wg.Wait()
 }
 
 func TestRouterEnableCors(t *testing.T) {
@@ -102,6 +435,69 @@ func TestRouterEnableCors(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestRouterEnableRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Add("X-Request-ID", "abc-123")
+
+	w := httptest.NewRecorder()
+
+	router := NewRouter()
+
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := requestid.FromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "abc-123", id)
+
+		w.WriteHeader(http.StatusOK)
+	}).Methods(http.MethodGet)
+
+	router.EnableRequestID()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "abc-123", w.Header().Get("X-Request-ID"))
+}
+
+func TestRouterEnableAccessLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	router := NewRouter()
+
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodGet)
+
+	router.EnableAccessLog(&accesslog.Configuration{
+		Format: accesslog.FormatLogfmt,
+		Output: buf,
+		Fields: []accesslog.Field{accesslog.FieldRoute, accesslog.FieldStatus},
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/", nil))
+
+	assert.Contains(t, buf.String(), "level=info route=/ status=204")
+}
+
+func TestRouterEnableRequestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	router := NewRouter()
+
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info().Msg("hello")
+	}).Methods(http.MethodGet)
+
+	router.EnableRequestLogger(&logging.Configuration{
+		Logger: &logger,
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/", nil))
+
+	assert.JSONEq(t, `{"level":"info","method":"GET","route":"/","message":"hello"}`, buf.String())
+}
+
 func TestRouterEnableProxy(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
 	req.Header.Add("X-Forwarded-For", "127.0.0.1")
@@ -356,3 +752,34 @@ func BenchmarkRouter(b *testing.B) {
 		router.ServeHTTP(w, req)
 	}
 }
+
+func TestRouterEnableMetricsWithOptions(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router := NewRouter()
+
+	err := router.EnableMetricsWithOptions(metrics.Options{
+		Namespace:  "admin",
+		Registerer: registry,
+	})
+	assert.NoError(t, err)
+
+	router.HandleFunc("/foo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `admin_http_request_total{method="GET",status="204"} 1`)
+
+	err = NewRouter().EnableMetricsWithOptions(metrics.Options{
+		Namespace:  "admin",
+		Registerer: registry,
+	})
+	assert.Error(t, err)
+}
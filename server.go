@@ -7,17 +7,30 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 )
 
+// WarmUpFunc is run by Server.Run once the listeners are started, the startup probe
+// fails until all the warm-up functions succeeded.
+type WarmUpFunc func(ctx context.Context) error
+
 // Server struct.
 type Server struct {
 	*Router
 	cfg         *Configuration
+	mtx         sync.Mutex
 	httpServer  *http.Server
 	httpsServer *http.Server
+	adminServer *http.Server
+	warmUps     []WarmUpFunc
+	listen      func(network string, address string) (net.Listener, error)
 }
 
 // New Server.
@@ -25,46 +38,139 @@ func New(cfg *Configuration) *Server {
 	return &Server{
 		Router: NewRouter(),
 		cfg:    cfg,
+		listen: net.Listen,
 	}
 }
 
-func (s *Server) runHTTPServer() error {
-	addr := s.cfg.HTTP.Addr()
+// AddWarmUp adds a warm-up function (ex: cache priming, migrations check) run by Run.
+func (s *Server) AddWarmUp(fn WarmUpFunc) {
+	s.warmUps = append(s.warmUps, fn)
+}
 
-	log.Info().Msgf("HTTP Server running on %s", addr)
+// warmUp runs the warm-up functions, the startup probe keeps failing when one of them fails.
+func (s *Server) warmUp(ctx context.Context) {
+	for _, fn := range s.warmUps {
+		if err := fn(ctx); err != nil {
+			log.Error().Err(err).Msg("Server warm-up failed")
 
-	s.httpServer = &http.Server{
-		Addr:              addr,
-		Handler:           s.Router,
-		ReadTimeout:       s.cfg.ReadTimeout,
-		ReadHeaderTimeout: s.cfg.ReadHeaderTimeout,
-		WriteTimeout:      s.cfg.WriteTimeout,
-		IdleTimeout:       s.cfg.IdleTimeout,
+			return
+		}
 	}
 
-	return s.httpServer.ListenAndServe() // nolint: wrapcheck
+	s.SetWarmingUp(false)
+
+	log.Info().Msg("Server warm-up completed")
+}
+
+// serverListener is a server with its bound listener, the TLS certificate is served when certFile is set.
+type serverListener struct {
+	name     string
+	server   *http.Server
+	listener net.Listener
+	certFile string
+	keyFile  string
 }
 
-func (s *Server) runHTTPSServer() error {
-	addr := s.cfg.HTTPS.Addr()
+func (l serverListener) serve() error {
+	log.Info().Msgf("%s Server running on %s", l.name, l.listener.Addr())
 
-	log.Info().Msgf("HTTPS Server running on %s", addr)
+	if l.certFile != "" {
+		return l.server.ServeTLS(l.listener, l.certFile, l.keyFile) // nolint: wrapcheck
+	}
 
-	s.httpsServer = &http.Server{
-		Addr:              addr,
-		Handler:           s.Router,
-		TLSConfig:         s.cfg.HTTPS.TLSConfig,
+	return l.server.Serve(l.listener) // nolint: wrapcheck
+}
+
+func (s *Server) newHTTPServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Handler:           handler,
 		ReadTimeout:       s.cfg.ReadTimeout,
 		ReadHeaderTimeout: s.cfg.ReadHeaderTimeout,
 		WriteTimeout:      s.cfg.WriteTimeout,
 		IdleTimeout:       s.cfg.IdleTimeout,
 	}
+}
+
+// listenAll builds the enabled servers and binds their listeners, nothing is left
+// listening when one of the addresses is not available.
+func (s *Server) listenAll() ([]serverListener, error) {
+	var listeners []serverListener
+
+	add := func(l serverListener, addr string) error {
+		ln, err := s.listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+
+		l.server.Addr = addr
+		l.listener = ln
+
+		listeners = append(listeners, l)
+
+		return nil
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var err error
+
+	if s.cfg.IsEnabled("http") {
+		s.httpServer = s.newHTTPServer(s.Router)
+
+		err = add(serverListener{
+			name:   "HTTP",
+			server: s.httpServer,
+		}, s.cfg.HTTP.Addr())
+	}
+
+	if err == nil && s.cfg.IsEnabled("https") {
+		s.httpsServer = s.newHTTPServer(s.Router)
+		s.httpsServer.TLSConfig = s.cfg.HTTPS.TLSConfig
+
+		err = add(serverListener{
+			name:     "HTTPS",
+			server:   s.httpsServer,
+			certFile: s.cfg.HTTPS.CertFile,
+			keyFile:  s.cfg.HTTPS.KeyFile,
+		}, s.cfg.HTTPS.Addr())
+	}
+
+	if err == nil && s.cfg.IsEnabled("admin") {
+		s.adminServer = s.newHTTPServer(s.adminHandler())
+
+		err = add(serverListener{
+			name:   "Admin",
+			server: s.adminServer,
+		}, s.cfg.Admin.Addr())
+	}
+
+	if err != nil {
+		for _, l := range listeners {
+			_ = l.listener.Close()
+		}
+
+		s.httpServer, s.httpsServer, s.adminServer = nil, nil, nil
+
+		return nil, err
+	}
 
-	return s.httpsServer.ListenAndServeTLS(s.cfg.HTTPS.CertFile, s.cfg.HTTPS.KeyFile) // nolint: wrapcheck
+	return listeners, nil
 }
 
-// Run Server.
-func (s *Server) Run() (err error) {
+// adminHandler serves the detailed health check response without authentication,
+// the admin listener must not be exposed publicly.
+func (s *Server) adminHandler() http.Handler {
+	router := mux.NewRouter()
+
+	router.Handle("/health/details", s.HealthCheckDetailsHandler("")).Methods(http.MethodGet, http.MethodHead)
+
+	return router
+}
+
+// Run Server, it blocks until the servers are shut down and returns the first
+// error of a server.
+func (s *Server) Run() error {
 	if !s.cfg.IsEnabled("http") && !s.cfg.IsEnabled("https") {
 		return errors.New("http or https server is not configured")
 	}
@@ -73,58 +179,104 @@ func (s *Server) Run() (err error) {
 
 	if s.cfg.HealthCheck {
 		s.EnableHealthCheck()
+
+		if s.cfg.HealthCheckInterval > 0 {
+			if err := s.EnableBackgroundHealthCheck(s.cfg.HealthCheckInterval); err != nil {
+				return err
+			}
+		}
 	}
 
 	if s.cfg.Metrics {
 		s.EnableMetrics()
 	}
 
+	if s.cfg.BuildInfo {
+		if err := s.EnableBuildInfo(BuildInfoOptions{}); err != nil {
+			return err
+		}
+	}
+
 	if s.cfg.Profiling {
 		s.EnableProfiling()
 	}
 
-	if s.cfg.IsEnabled("http") {
-		go func() {
-			if e := s.runHTTPServer(); e != nil {
-				err = e
-			}
-		}()
+	if len(s.warmUps) > 0 {
+		s.SetWarmingUp(true)
 	}
 
-	if s.cfg.IsEnabled("https") {
-		go func() {
-			if e := s.runHTTPSServer(); e != nil {
-				err = e
-			}
-		}()
+	listeners, err := s.listenAll()
+	if err != nil {
+		return err
+	}
+
+	errs := make(chan error, len(listeners))
+
+	for _, l := range listeners {
+		go func(l serverListener) {
+			errs <- l.serve()
+		}(l)
 	}
 
-	ch := make(chan struct{})
-	<-ch
+	if len(s.warmUps) > 0 {
+		go s.warmUp(context.Background())
+	}
+
+	for range listeners {
+		if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	}
 
 	return nil
 }
 
-// Shutdown server.
+// Shutdown server, the readiness probe fails during the ShutdownDelay before
+// the servers stop accepting connections.
 func (s *Server) Shutdown() (err error) {
-	if s.httpServer != nil {
+	s.SetShuttingDown()
+
+	defer s.DisableBackgroundHealthCheck()
+
+	if s.cfg.ShutdownDelay > 0 {
+		log.Info().Msgf("Waiting %s before shutting down...", s.cfg.ShutdownDelay)
+
+		time.Sleep(s.cfg.ShutdownDelay)
+	}
+
+	s.mtx.Lock()
+	httpServer, httpsServer, adminServer := s.httpServer, s.httpsServer, s.adminServer
+	s.mtx.Unlock()
+
+	if httpServer != nil {
 		log.Info().Msg("Shutting down HTTP server...")
 
 		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
 		defer cancel()
 
-		if e := s.httpServer.Shutdown(ctx); err != nil {
+		if e := httpServer.Shutdown(ctx); e != nil {
 			err = e
 		}
 	}
 
-	if s.httpsServer != nil {
+	if httpsServer != nil {
 		log.Info().Msg("Shutting down HTTPS server...")
 
 		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
 		defer cancel()
 
-		if e := s.httpsServer.Shutdown(ctx); err != nil {
+		if e := httpsServer.Shutdown(ctx); e != nil {
+			err = e
+		}
+	}
+
+	if adminServer != nil {
+		log.Info().Msg("Shutting down Admin server...")
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+		defer cancel()
+
+		if e := adminServer.Shutdown(ctx); e != nil {
 			err = e
 		}
 	}
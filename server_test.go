@@ -5,7 +5,11 @@
 package server
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
+	"io"
+	"net"
 	"net/http"
 	"testing"
 	"time"
@@ -23,6 +27,29 @@ func httpClient() *http.Client {
 	}
 }
 
+// listenLocal makes the server listen on random local ports, the bound address of each
+// configured address is sent on its channel once listening.
+func listenLocal(s *Server, addrs ...string) map[string]chan string {
+	bound := make(map[string]chan string, len(addrs))
+
+	for _, addr := range addrs {
+		bound[addr] = make(chan string, 1)
+	}
+
+	s.listen = func(network string, address string) (net.Listener, error) {
+		ln, err := net.Listen(network, "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+
+		bound[address] <- ln.Addr().String()
+
+		return ln, nil
+	}
+
+	return bound
+}
+
 func TestServerNotConfigured(t *testing.T) {
 	s := New(&Configuration{})
 
@@ -38,6 +65,7 @@ func TestServerHTTP(t *testing.T) {
 		Profiling:   true,
 		Metrics:     true,
 		HealthCheck: true,
+		BuildInfo:   true,
 	})
 
 	s.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -59,8 +87,188 @@ func TestServerHTTP(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
+	resp, err = http.Get("http://localhost:12456/info")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	err = s.Shutdown()
+	assert.NoError(t, err)
+}
+
+func TestServerAdmin(t *testing.T) {
+	s := New(&Configuration{
+		HTTP: &HTTPConfiguration{
+			Port: 12456,
+		},
+		Admin: &HTTPConfiguration{
+			Host: "127.0.0.1",
+			Port: 12458,
+		},
+		HealthCheck: true,
+	})
+
+	bound := listenLocal(s, ":12456", "127.0.0.1:12458")
+
+	err := s.AddHealthCheck("redis", HealthCheckHandlerFunc(func() bool {
+		return true
+	}))
+	assert.NoError(t, err)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- s.Run()
+	}()
+
+	httpAddr := <-bound[":12456"]
+	adminAddr := <-bound["127.0.0.1:12458"]
+
+	resp, err := http.Get("http://" + httpAddr + "/health/details")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = http.Get("http://" + adminAddr + "/health/details")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "redis")
+	assert.Contains(t, string(body), "uptime")
+	resp.Body.Close()
+
+	err = s.Shutdown()
+	assert.NoError(t, err)
+
+	assert.NoError(t, <-done)
+}
+
+func TestServerAdminShutdownError(t *testing.T) {
+	s := New(&Configuration{
+		HTTP: &HTTPConfiguration{
+			Port: 12456,
+		},
+		Admin: &HTTPConfiguration{
+			Host: "127.0.0.1",
+			Port: 12458,
+		},
+		ShutdownTimeout: 50 * time.Millisecond,
+	})
+
+	bound := listenLocal(s, ":12456", "127.0.0.1:12458")
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	err := s.AddHealthChecker("slow", HealthCheckerFunc(func(ctx context.Context) HealthCheckResult {
+		close(started)
+
+		<-release
+
+		return HealthCheckPass()
+	}), HealthCheckOptions{})
+	assert.NoError(t, err)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- s.Run()
+	}()
+
+	<-bound[":12456"]
+	adminAddr := <-bound["127.0.0.1:12458"]
+
+	// Keep a request in flight on the admin listener.
+	go func() {
+		resp, err := http.Get("http://" + adminAddr + "/health/details")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+
 	err = s.Shutdown()
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the admin shutdown error is returned")
+
+	assert.NoError(t, <-done)
+}
+
+func TestServerProbes(t *testing.T) {
+	s := New(&Configuration{
+		HTTP: &HTTPConfiguration{
+			Port: 12456,
+		},
+		ShutdownDelay: 300 * time.Millisecond,
+		HealthCheck:   true,
+	})
+
+	warmedUp := make(chan struct{})
+
+	s.AddWarmUp(func(ctx context.Context) error {
+		<-warmedUp
+
+		return nil
+	})
+
+	go func() {
+		err := s.Run()
+		assert.NoError(t, err)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	resp, err := http.Get("http://localhost:12456/health/startup")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	close(warmedUp)
+
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get("http://localhost:12456/health/startup")
+
+		return err == nil && resp.StatusCode == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	resp, err = http.Get("http://localhost:12456/health/ready")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		err := s.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	resp, err = http.Get("http://localhost:12456/health/ready")
 	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	resp, err = http.Get("http://localhost:12456/health/live")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	<-done
+}
+
+func TestServerWarmUpFailed(t *testing.T) {
+	s := New(&Configuration{})
+
+	s.AddWarmUp(func(ctx context.Context) error {
+		return errors.New("cache unavailable")
+	})
+
+	s.SetWarmingUp(true)
+	s.warmUp(context.Background())
+
+	assert.Equal(t, HealthStatusFail, s.healthCheck(context.Background(), HealthGroupStartup).Status)
 }
 
 func TestServerHTTPS(t *testing.T) {
@@ -0,0 +1,152 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCookie is returned by a Codec when the cookie value cannot be authenticated.
+var ErrInvalidCookie = errors.New("invalid cookie")
+
+// Codec authenticates the cookie values, the cookie name is bound to the value
+// so a value cannot be replayed in another cookie.
+type Codec interface {
+	Encode(name string, value string) (string, error)
+	Decode(name string, value string) (string, error)
+}
+
+// SignedCodec signs the cookie values with HMAC-SHA256, the values are readable by the client.
+// The first key is used to sign, all the keys are used to verify for key rotation.
+type SignedCodec struct {
+	keys [][]byte
+}
+
+// NewSignedCodec constructor.
+func NewSignedCodec(keys ...[]byte) *SignedCodec {
+	return &SignedCodec{
+		keys: keys,
+	}
+}
+
+// Encode implements Codec.
+func (c *SignedCodec) Encode(name string, value string) (string, error) {
+	if len(c.keys) == 0 {
+		return "", errors.New("no signing key")
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(value)) + "." +
+		base64.RawURLEncoding.EncodeToString(sign(c.keys[0], name, value)), nil
+}
+
+// Decode implements Codec.
+func (c *SignedCodec) Decode(name string, value string) (string, error) {
+	encoded, encodedSignature, ok := strings.Cut(value, ".")
+	if !ok {
+		return "", ErrInvalidCookie
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	for _, key := range c.keys {
+		if hmac.Equal(sign(key, name, string(b)), signature) {
+			return string(b), nil
+		}
+	}
+
+	return "", ErrInvalidCookie
+}
+
+func sign(key []byte, name string, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+
+	return mac.Sum(nil)
+}
+
+// EncryptedCodec encrypts the cookie values with AES-GCM.
+// The first key is used to encrypt, all the keys are used to decrypt for key rotation.
+type EncryptedCodec struct {
+	aeads []cipher.AEAD
+}
+
+// NewEncryptedCodec constructor, the keys must be 16, 24 or 32 bytes long.
+func NewEncryptedCodec(keys ...[]byte) (*EncryptedCodec, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no encryption key")
+	}
+
+	aeads := make([]cipher.AEAD, 0, len(keys))
+
+	for _, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+
+		aeads = append(aeads, aead)
+	}
+
+	return &EncryptedCodec{
+		aeads: aeads,
+	}, nil
+}
+
+// Encode implements Codec.
+func (c *EncryptedCodec) Encode(name string, value string) (string, error) {
+	aead := c.aeads[0]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(value), []byte(name))), nil
+}
+
+// Decode implements Codec.
+func (c *EncryptedCodec) Decode(name string, value string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	for _, aead := range c.aeads {
+		if len(b) < aead.NonceSize() {
+			continue
+		}
+
+		plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], []byte(name))
+		if err == nil {
+			return string(plaintext), nil
+		}
+	}
+
+	return "", ErrInvalidCookie
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedCodec(t *testing.T) {
+	codec := NewSignedCodec([]byte("key1"))
+
+	value, err := codec.Encode("session", "foo")
+	assert.NoError(t, err)
+
+	decoded, err := codec.Decode("session", value)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", decoded)
+
+	_, err = codec.Decode("other", value)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = codec.Decode("session", "Zm9v.YmFy")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = codec.Decode("session", "foo")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	// Key rotation
+	rotated := NewSignedCodec([]byte("key2"), []byte("key1"))
+
+	decoded, err = rotated.Decode("session", value)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", decoded)
+
+	value, err = rotated.Encode("session", "bar")
+	assert.NoError(t, err)
+
+	_, err = codec.Decode("session", value)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = NewSignedCodec().Encode("session", "foo")
+	assert.Error(t, err)
+}
+
+func TestEncryptedCodec(t *testing.T) {
+	key1 := []byte("0123456789abcdef0123456789abcdef")
+	key2 := []byte("fedcba9876543210fedcba9876543210")
+
+	codec, err := NewEncryptedCodec(key1)
+	assert.NoError(t, err)
+
+	value, err := codec.Encode("session", "foo")
+	assert.NoError(t, err)
+	assert.NotContains(t, value, "foo")
+
+	decoded, err := codec.Decode("session", value)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", decoded)
+
+	_, err = codec.Decode("other", value)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = codec.Decode("session", "!!")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = codec.Decode("session", "Zm9v")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	// Key rotation
+	rotated, err := NewEncryptedCodec(key2, key1)
+	assert.NoError(t, err)
+
+	decoded, err = rotated.Decode("session", value)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", decoded)
+
+	_, err = NewEncryptedCodec([]byte("short"))
+	assert.Error(t, err)
+
+	_, err = NewEncryptedCodec()
+	assert.Error(t, err)
+}
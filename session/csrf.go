@@ -0,0 +1,196 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/euskadi31/go-server/response"
+)
+
+var (
+	// DefaultCSRFHeader is the request header containing the CSRF token.
+	DefaultCSRFHeader = "X-CSRF-Token"
+
+	// DefaultCSRFFormField is the form field containing the CSRF token.
+	DefaultCSRFFormField = "csrf_token"
+
+	// DefaultCSRFCookieName is the cookie exposing the CSRF token to the scripts.
+	DefaultCSRFCookieName = "XSRF-TOKEN"
+
+	// DefaultCSRFSecretCookieName is the HttpOnly cookie of the per-client secret
+	// binding the double-submit token to the client.
+	DefaultCSRFSecretCookieName = "csrf_secret"
+)
+
+// CSRFConfiguration struct.
+type CSRFConfiguration struct {
+	Header    string
+	FormField string
+	// Codec signing the double-submit cookie when no session is available,
+	// a random key is used when nil.
+	Codec      Codec
+	CookieName string
+	// SecretCookieName is the HttpOnly cookie of the per-client secret signed with the
+	// double-submit token, DefaultCSRFSecretCookieName is used when empty.
+	SecretCookieName string
+	CookiePath       string
+	CookieDomain     string
+	Secure           bool
+	SameSite         http.SameSite
+}
+
+// CSRF protects the unsafe methods against cross-site request forgery, the token sent
+// in the header or the form field must match the synchronizer token of the session.
+// Without session Handler, the token is compared to the signed double-submit cookie,
+// the signature is bound to a per-client secret kept in an HttpOnly cookie.
+// The token is exposed in a cookie readable by the scripts and with TokenFromContext.
+func CSRF(cfg *CSRFConfiguration) func(http.Handler) http.Handler {
+	if cfg.Header == "" {
+		cfg.Header = DefaultCSRFHeader
+	}
+
+	if cfg.FormField == "" {
+		cfg.FormField = DefaultCSRFFormField
+	}
+
+	if cfg.Codec == nil {
+		cfg.Codec = NewSignedCodec(randomKey())
+	}
+
+	if cfg.CookieName == "" {
+		cfg.CookieName = DefaultCSRFCookieName
+	}
+
+	if cfg.SecretCookieName == "" {
+		cfg.SecretCookieName = DefaultCSRFSecretCookieName
+	}
+
+	if cfg.CookiePath == "" {
+		cfg.CookiePath = "/"
+	}
+
+	if cfg.SameSite == 0 {
+		cfg.SameSite = http.SameSiteLaxMode
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := csrfToken(cfg, w, r)
+
+			if !isSafeMethod(r.Method) && !validCSRFToken(token, submittedCSRFToken(cfg, r)) {
+				response.Failure(w, http.StatusForbidden, response.ErrorMessage{
+					Code:    http.StatusForbidden,
+					Message: "Invalid CSRF token",
+				})
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), csrfContextKey, token)))
+		})
+	}
+}
+
+// TokenFromContext returns the CSRF token to embed in the forms.
+func TokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(csrfContextKey).(string)
+
+	return token
+}
+
+// csrfToken returns the expected token and sets the cookie exposing it if needed.
+func csrfToken(cfg *CSRFConfiguration, w http.ResponseWriter, r *http.Request) string {
+	current := ""
+
+	cookie, err := r.Cookie(cfg.CookieName)
+	if err == nil {
+		current = cookie.Value
+	}
+
+	if session, ok := FromContext(r.Context()); ok {
+		token := session.CSRFToken()
+
+		if current != token {
+			http.SetCookie(w, csrfCookie(cfg, token))
+		}
+
+		return token
+	}
+
+	secret := ""
+
+	if cookie, err := r.Cookie(cfg.SecretCookieName); err == nil {
+		secret = cookie.Value
+	}
+
+	if secret == "" {
+		// A new client, the tokens of the other clients are not valid for it.
+		secret = generateToken()
+
+		secretCookie := csrfCookie(cfg, secret)
+		secretCookie.Name = cfg.SecretCookieName
+		secretCookie.HttpOnly = true
+
+		http.SetCookie(w, secretCookie)
+	} else if current != "" {
+		if _, err := cfg.Codec.Decode(csrfBinding(cfg, secret), current); err == nil {
+			return current
+		}
+	}
+
+	value, err := cfg.Codec.Encode(csrfBinding(cfg, secret), generateToken())
+	if err != nil {
+		return ""
+	}
+
+	http.SetCookie(w, csrfCookie(cfg, value))
+
+	return value
+}
+
+// csrfBinding returns the name signed with the double-submit token, it binds the token
+// to the secret of the client without exposing the secret.
+func csrfBinding(cfg *CSRFConfiguration, secret string) string {
+	return cfg.CookieName + ":" + secret
+}
+
+func csrfCookie(cfg *CSRFConfiguration, value string) *http.Cookie {
+	return &http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    value,
+		Path:     cfg.CookiePath,
+		Domain:   cfg.CookieDomain,
+		Secure:   cfg.Secure,
+		SameSite: cfg.SameSite,
+	}
+}
+
+func submittedCSRFToken(cfg *CSRFConfiguration, r *http.Request) string {
+	if token := r.Header.Get(cfg.Header); token != "" {
+		return token
+	}
+
+	return r.PostFormValue(cfg.FormField)
+}
+
+func validCSRFToken(expected string, submitted string) bool {
+	if expected == "" || submitted == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(submitted)) == 1
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/justinas/alice"
+	"github.com/stretchr/testify/assert"
+)
+
+func csrfCookieFrom(resp *http.Response) *http.Cookie {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == DefaultCSRFCookieName {
+			return cookie
+		}
+	}
+
+	return nil
+}
+
+func TestCSRFWithSession(t *testing.T) {
+	server := httptest.NewServer(alice.New(
+		Handler(&Configuration{}),
+		CSRF(&CSRFConfiguration{}),
+	).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, TokenFromContext(r.Context()))
+	}))
+	defer server.Close()
+
+	jar, err := cookiejar.New(nil)
+	assert.NoError(t, err)
+
+	client := &http.Client{Jar: jar}
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	token := string(body)
+	assert.NotEmpty(t, token)
+
+	cookie := csrfCookieFrom(resp)
+	assert.NotNil(t, cookie)
+	assert.False(t, cookie.HttpOnly)
+	assert.Equal(t, token, cookie.Value)
+
+	// The token is stable for the session.
+	resp, err = client.Get(server.URL)
+	assert.NoError(t, err)
+
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, token, string(body))
+	assert.Nil(t, csrfCookieFrom(resp))
+
+	// Header
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	assert.NoError(t, err)
+
+	req.Header.Set(DefaultCSRFHeader, token)
+
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Form field
+	resp, err = client.PostForm(server.URL, url.Values{
+		DefaultCSRFFormField: []string{token},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Missing token
+	resp, err = client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, `{"error":{"code":403,"message":"Invalid CSRF token"}}`+"\n", string(body))
+
+	// Token of another session
+	req, err = http.NewRequest(http.MethodDelete, server.URL, nil)
+	assert.NoError(t, err)
+
+	req.Header.Set(DefaultCSRFHeader, generateToken())
+
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Cross-site request without the session cookie
+	req, err = http.NewRequest(http.MethodPost, server.URL, nil)
+	assert.NoError(t, err)
+
+	req.Header.Set(DefaultCSRFHeader, token)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestCSRFDoubleSubmit(t *testing.T) {
+	handler := CSRF(&CSRFConfiguration{
+		Codec: NewSignedCodec([]byte("secret")),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, TokenFromContext(r.Context()))
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 2)
+
+	cookie := csrfCookieFrom(w.Result())
+	assert.False(t, cookie.HttpOnly)
+
+	token := cookie.Value
+	assert.Equal(t, token, w.Body.String())
+
+	// Valid double-submit
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "http://example.com/", nil)
+
+	for _, c := range cookies {
+		r.AddCookie(c)
+	}
+
+	r.Header.Set(DefaultCSRFHeader, token)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Result().Cookies())
+
+	// Header without cookie
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "http://example.com/", nil)
+	r.Header.Set(DefaultCSRFHeader, token)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Token cookie without the client secret
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "http://example.com/", nil)
+	r.AddCookie(cookie)
+	r.Header.Set(DefaultCSRFHeader, token)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Forged cookie
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPut, "http://example.com/", nil)
+	r.AddCookie(&http.Cookie{Name: DefaultCSRFCookieName, Value: "forged"})
+	r.Header.Set(DefaultCSRFHeader, "forged")
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCSRFDoubleSubmitReplayedOnAnotherClient(t *testing.T) {
+	handler := CSRF(&CSRFConfiguration{
+		Codec: NewSignedCodec([]byte("secret")),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	visit := func() []*http.Cookie {
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/", nil))
+
+		return w.Result().Cookies()
+	}
+
+	attacker := visit()
+	victim := visit()
+
+	var attackerToken *http.Cookie
+
+	for _, c := range attacker {
+		if c.Name == DefaultCSRFCookieName {
+			attackerToken = c
+		}
+	}
+
+	// The attacker plants its token cookie in the browser of the victim.
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "http://example.com/", nil)
+
+	for _, c := range victim {
+		if c.Name == DefaultCSRFSecretCookieName {
+			assert.True(t, c.HttpOnly)
+
+			r.AddCookie(c)
+		}
+	}
+
+	r.AddCookie(attackerToken)
+	r.Header.Set(DefaultCSRFHeader, attackerToken.Value)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-server/response"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// DefaultCookieName of the session cookie.
+	DefaultCookieName = "session"
+
+	// DefaultIdleTimeout sets the expiration of a session without activity (30m).
+	DefaultIdleTimeout = 30 * time.Minute
+
+	// DefaultAbsoluteTimeout sets the maximum lifetime of a session (12h).
+	DefaultAbsoluteTimeout = 12 * time.Hour
+)
+
+// Configuration struct.
+type Configuration struct {
+	// Store of the sessions, a MemoryStore is used when nil.
+	Store Store
+	// Codec of the session cookie, a SignedCodec with a random key is used when nil
+	// so the sessions do not survive a restart.
+	Codec           Codec
+	CookieName      string
+	CookiePath      string
+	CookieDomain    string
+	Secure          bool
+	SameSite        http.SameSite
+	IdleTimeout     time.Duration
+	AbsoluteTimeout time.Duration
+}
+
+// Handler loads the session of the request in the context and saves it before
+// the response headers are written. Sessions are only stored once modified.
+func Handler(cfg *Configuration) func(http.Handler) http.Handler {
+	return newManager(cfg).handler
+}
+
+type manager struct {
+	cfg *Configuration
+	now func() time.Time
+}
+
+func newManager(cfg *Configuration) *manager {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+
+	if cfg.Codec == nil {
+		cfg.Codec = NewSignedCodec(randomKey())
+	}
+
+	if cfg.CookieName == "" {
+		cfg.CookieName = DefaultCookieName
+	}
+
+	if cfg.CookiePath == "" {
+		cfg.CookiePath = "/"
+	}
+
+	if cfg.SameSite == 0 {
+		cfg.SameSite = http.SameSiteLaxMode
+	}
+
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = DefaultIdleTimeout
+	}
+
+	if cfg.AbsoluteTimeout == 0 {
+		cfg.AbsoluteTimeout = DefaultAbsoluteTimeout
+	}
+
+	return &manager{
+		cfg: cfg,
+		now: time.Now,
+	}
+}
+
+func (m *manager) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, err := m.load(r)
+		if err != nil {
+			log.Error().Err(err).Msg("Session load failed")
+
+			response.InternalServerFailure(w)
+
+			return
+		}
+
+		sw := &responseWriter{
+			ResponseWriter: w,
+			commit: func() {
+				m.save(r.Context(), w, session)
+			},
+		}
+
+		next.ServeHTTP(sw, r.WithContext(ToContext(r.Context(), session)))
+
+		sw.commitOnce()
+	})
+}
+
+// load returns the session of the cookie, or a new session if it is missing, invalid or expired.
+func (m *manager) load(r *http.Request) (*Session, error) {
+	now := m.now()
+
+	cookie, err := r.Cookie(m.cfg.CookieName)
+	if err != nil {
+		return newSession(now), nil
+	}
+
+	id, err := m.cfg.Codec.Decode(m.cfg.CookieName, cookie.Value)
+	if err != nil {
+		return newSession(now), nil
+	}
+
+	b, err := m.cfg.Store.Load(r.Context(), id)
+	if errors.Is(err, ErrNotFound) {
+		return newSession(now), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{
+		id: id,
+	}
+
+	if err := json.Unmarshal(b, &session.data); err != nil {
+		log.Warn().Err(err).Msg("Session data is invalid")
+
+		return newSession(now), m.cfg.Store.Delete(r.Context(), id)
+	}
+
+	if m.expired(session, now) {
+		if err := m.cfg.Store.Delete(r.Context(), id); err != nil {
+			return nil, err
+		}
+
+		return newSession(now), nil
+	}
+
+	session.data.AccessedAt = now
+
+	return session, nil
+}
+
+func (m *manager) expired(session *Session, now time.Time) bool {
+	return now.After(session.data.AccessedAt.Add(m.cfg.IdleTimeout)) ||
+		now.After(session.data.CreatedAt.Add(m.cfg.AbsoluteTimeout))
+}
+
+func (m *manager) expiresAt(session *Session) time.Time {
+	idle := session.data.AccessedAt.Add(m.cfg.IdleTimeout)
+	absolute := session.data.CreatedAt.Add(m.cfg.AbsoluteTimeout)
+
+	if idle.Before(absolute) {
+		return idle
+	}
+
+	return absolute
+}
+
+// save stores the session and sets the cookie, the errors are logged
+// because the response is already committed.
+func (m *manager) save(ctx context.Context, w http.ResponseWriter, session *Session) {
+	session.mtx.Lock()
+	defer session.mtx.Unlock()
+
+	if session.previousID != "" || session.destroyed {
+		previousID := session.previousID
+		if previousID == "" {
+			previousID = session.id
+		}
+
+		if err := m.cfg.Store.Delete(ctx, previousID); err != nil {
+			log.Error().Err(err).Msg("Session delete failed")
+		}
+	}
+
+	if session.destroyed {
+		if !session.isNew {
+			http.SetCookie(w, m.cookie("", -1, time.Time{}))
+		}
+
+		return
+	}
+
+	// Anonymous sessions without data are not stored.
+	if session.isNew && !session.modified {
+		return
+	}
+
+	b, err := json.Marshal(session.data)
+	if err != nil {
+		log.Error().Err(err).Msg("Session encode failed")
+
+		return
+	}
+
+	expiresAt := m.expiresAt(session)
+
+	if err := m.cfg.Store.Save(ctx, session.id, b, expiresAt); err != nil {
+		log.Error().Err(err).Msg("Session save failed")
+
+		return
+	}
+
+	if !session.isNew && session.previousID == "" {
+		return
+	}
+
+	value, err := m.cfg.Codec.Encode(m.cfg.CookieName, session.id)
+	if err != nil {
+		log.Error().Err(err).Msg("Session cookie encode failed")
+
+		return
+	}
+
+	http.SetCookie(w, m.cookie(value, 0, session.data.CreatedAt.Add(m.cfg.AbsoluteTimeout)))
+}
+
+func (m *manager) cookie(value string, maxAge int, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     m.cfg.CookieName,
+		Value:    value,
+		Path:     m.cfg.CookiePath,
+		Domain:   m.cfg.CookieDomain,
+		Expires:  expires,
+		MaxAge:   maxAge,
+		Secure:   m.cfg.Secure,
+		HttpOnly: true,
+		SameSite: m.cfg.SameSite,
+	}
+}
+
+// responseWriter commits the session before the response headers are written.
+type responseWriter struct {
+	http.ResponseWriter
+	commit func()
+	once   sync.Once
+}
+
+func (w *responseWriter) commitOnce() {
+	w.once.Do(w.commit)
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *responseWriter) WriteHeader(status int) {
+	w.commitOnce()
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.commitOnce()
+
+	return w.ResponseWriter.Write(b) // nolint: wrapcheck
+}
+
+// Flush implements http.Flusher.
+func (w *responseWriter) Flush() {
+	w.commitOnce()
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the original http.ResponseWriter for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
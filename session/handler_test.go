@@ -0,0 +1,273 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-server/authentication"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestServer(t *testing.T, m *manager) (*httptest.Server, *http.Client) {
+	t.Helper()
+
+	router := mux.NewRouter()
+	router.Use(m.handler)
+
+	router.HandleFunc("/visit", func(w http.ResponseWriter, r *http.Request) {
+		session, _ := FromContext(r.Context())
+
+		count, _ := session.Get("count").(float64)
+		session.Set("count", count+1)
+
+		w.Header().Set("X-Session-ID", session.ID())
+		w.WriteHeader(http.StatusOK)
+
+		_, _ = io.WriteString(w, session.ID())
+	})
+
+	router.HandleFunc("/count", func(w http.ResponseWriter, r *http.Request) {
+		session, _ := FromContext(r.Context())
+
+		count, _ := session.Get("count").(float64)
+
+		w.Header().Set("X-Count", strconv.Itoa(int(count)))
+		w.Header().Set("X-Session-ID", session.ID())
+	})
+
+	router.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		session, _ := FromContext(r.Context())
+
+		session.Login(&authentication.Principal{
+			Subject: "alice",
+			Roles:   []string{"admin"},
+		})
+
+		w.Header().Set("X-Session-ID", session.ID())
+	})
+
+	router.HandleFunc("/logout", func(w http.ResponseWriter, r *http.Request) {
+		session, _ := FromContext(r.Context())
+
+		session.Logout()
+	})
+
+	router.Handle("/me", authentication.Handler(&authentication.Configuration{
+		Realm:  "Admin",
+		Public: authentication.NoPublic,
+	}, Provider())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, authentication.SubjectFromContext(r.Context()))
+	})))
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	jar, err := cookiejar.New(nil)
+	assert.NoError(t, err)
+
+	return server, &http.Client{Jar: jar}
+}
+
+func get(t *testing.T, client *http.Client, url string) *http.Response {
+	t.Helper()
+
+	resp, err := client.Get(url)
+	assert.NoError(t, err)
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	return resp
+}
+
+func TestHandler(t *testing.T) {
+	store := NewMemoryStore()
+
+	m := newManager(&Configuration{
+		Store: store,
+	})
+
+	server, client := newTestServer(t, m)
+
+	// Unmodified sessions are not stored.
+	resp := get(t, client, server.URL+"/count")
+	assert.Empty(t, resp.Cookies())
+	assert.Empty(t, store.sessions)
+
+	resp = get(t, client, server.URL+"/visit")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cookies := resp.Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, DefaultCookieName, cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	assert.Equal(t, "/", cookies[0].Path)
+
+	id := resp.Header.Get("X-Session-ID")
+	assert.Len(t, store.sessions, 1)
+	assert.Contains(t, store.sessions, id)
+
+	resp = get(t, client, server.URL+"/visit")
+	assert.Empty(t, resp.Cookies())
+	assert.Equal(t, id, resp.Header.Get("X-Session-ID"))
+
+	resp = get(t, client, server.URL+"/count")
+	assert.Equal(t, "2", resp.Header.Get("X-Count"))
+}
+
+func TestHandlerLogin(t *testing.T) {
+	store := NewMemoryStore()
+
+	m := newManager(&Configuration{
+		Store: store,
+	})
+
+	server, client := newTestServer(t, m)
+
+	resp := get(t, client, server.URL+"/me")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = get(t, client, server.URL+"/visit")
+	id := resp.Header.Get("X-Session-ID")
+
+	// The session ID is renewed on login and the values are kept.
+	resp = get(t, client, server.URL+"/login")
+	assert.Len(t, resp.Cookies(), 1)
+
+	loggedID := resp.Header.Get("X-Session-ID")
+	assert.NotEqual(t, id, loggedID)
+	assert.NotContains(t, store.sessions, id)
+	assert.Contains(t, store.sessions, loggedID)
+
+	resp = get(t, client, server.URL+"/count")
+	assert.Equal(t, "1", resp.Header.Get("X-Count"))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/me", nil)
+	assert.NoError(t, err)
+
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "alice", string(body))
+
+	// Logout removes the session and expires the cookie.
+	resp = get(t, client, server.URL+"/logout")
+	assert.Len(t, resp.Cookies(), 1)
+	assert.Equal(t, -1, resp.Cookies()[0].MaxAge)
+	assert.Empty(t, store.sessions)
+
+	resp = get(t, client, server.URL+"/me")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestHandlerExpiration(t *testing.T) {
+	store := NewMemoryStore()
+
+	m := newManager(&Configuration{
+		Store:           store,
+		IdleTimeout:     10 * time.Minute,
+		AbsoluteTimeout: time.Hour,
+	})
+
+	now := time.Now()
+	m.now = func() time.Time {
+		return now
+	}
+	store.now = m.now
+
+	server, client := newTestServer(t, m)
+
+	resp := get(t, client, server.URL+"/visit")
+	id := resp.Header.Get("X-Session-ID")
+
+	// The activity extends the idle timeout.
+	for i := 0; i < 5; i++ {
+		now = now.Add(9 * time.Minute)
+
+		resp = get(t, client, server.URL+"/count")
+		assert.Equal(t, id, resp.Header.Get("X-Session-ID"))
+	}
+
+	// Idle timeout
+	now = now.Add(11 * time.Minute)
+
+	resp = get(t, client, server.URL+"/count")
+	assert.NotEqual(t, id, resp.Header.Get("X-Session-ID"))
+
+	resp = get(t, client, server.URL+"/visit")
+	id = resp.Header.Get("X-Session-ID")
+
+	// Absolute timeout
+	for i := 0; i < 6; i++ {
+		now = now.Add(9 * time.Minute)
+
+		resp = get(t, client, server.URL+"/count")
+		assert.Equal(t, id, resp.Header.Get("X-Session-ID"))
+	}
+
+	now = now.Add(9 * time.Minute)
+
+	resp = get(t, client, server.URL+"/count")
+	assert.NotEqual(t, id, resp.Header.Get("X-Session-ID"))
+	assert.Equal(t, "0", resp.Header.Get("X-Count"))
+}
+
+func TestHandlerWithInvalidCookie(t *testing.T) {
+	m := newManager(&Configuration{})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	r.AddCookie(&http.Cookie{Name: DefaultCookieName, Value: "forged"})
+
+	m.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, ok := FromContext(r.Context())
+		assert.True(t, ok)
+		assert.True(t, session.IsNew())
+	})).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandlerWithStoreError(t *testing.T) {
+	store := &MockStore{}
+	store.On("Load", mock.Anything, "foo").Return(nil, errors.New("fail"))
+
+	codec := NewSignedCodec([]byte("secret"))
+
+	value, err := codec.Encode(DefaultCookieName, "foo")
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	r.AddCookie(&http.Cookie{Name: DefaultCookieName, Value: value})
+
+	Handler(&Configuration{
+		Store: store,
+		Codec: codec,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("the handler must not be called")
+	})).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	store.AssertExpectations(t)
+}
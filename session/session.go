@@ -0,0 +1,219 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-server/authentication"
+)
+
+type key int
+
+const (
+	contextKey key = iota
+	csrfContextKey
+)
+
+// Session is the server-side state of a browser, it is safe for concurrent use.
+type Session struct {
+	mtx        sync.Mutex
+	id         string
+	previousID string
+	data       record
+	isNew      bool
+	modified   bool
+	destroyed  bool
+}
+
+// record is the data saved in the Store.
+type record struct {
+	Values     map[string]interface{}    `json:"values,omitempty"`
+	Principal  *authentication.Principal `json:"principal,omitempty"`
+	CSRFToken  string                    `json:"csrf_token,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	AccessedAt time.Time                 `json:"accessed_at"`
+}
+
+func newSession(now time.Time) *Session {
+	return &Session{
+		id:    generateToken(),
+		isNew: true,
+		data: record{
+			CreatedAt:  now,
+			AccessedAt: now,
+		},
+	}
+}
+
+// ID returns the session ID.
+func (s *Session) ID() string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.id
+}
+
+// IsNew returns true if the session has been created by the current request.
+func (s *Session) IsNew() bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.isNew
+}
+
+// CreatedAt returns the creation time of the session.
+func (s *Session) CreatedAt() time.Time {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.data.CreatedAt
+}
+
+// Get returns the value of the key, or nil if the session does not have it.
+// Values are JSON encoded in the store, numbers are decoded as float64.
+func (s *Session) Get(key string) interface{} {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.data.Values[key]
+}
+
+// Set the value of the key, the value must be JSON serializable.
+func (s *Session) Set(key string, value interface{}) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.data.Values == nil {
+		s.data.Values = make(map[string]interface{})
+	}
+
+	s.data.Values[key] = value
+	s.modified = true
+}
+
+// Delete the value of the key.
+func (s *Session) Delete(key string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.data.Values, key)
+	s.modified = true
+}
+
+// Principal returns the principal logged in the session.
+func (s *Session) Principal() (*authentication.Principal, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.data.Principal, s.data.Principal != nil
+}
+
+// Login stores the principal in the session and renews the session ID
+// and the CSRF token to prevent session fixation, the login response
+// should return the new token given by CSRFToken.
+func (s *Session) Login(principal *authentication.Principal) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data.Principal = principal
+	s.renew()
+}
+
+// Logout destroys the session.
+func (s *Session) Logout() {
+	s.Destroy()
+}
+
+// Renew generates a new session ID and CSRF token while keeping the values,
+// it must be called when the privileges of the session change.
+func (s *Session) Renew() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.renew()
+}
+
+func (s *Session) renew() {
+	if !s.isNew && s.previousID == "" {
+		s.previousID = s.id
+	}
+
+	s.id = generateToken()
+	s.data.CSRFToken = ""
+	s.modified = true
+}
+
+// Destroy removes the session from the store and expires the cookie.
+func (s *Session) Destroy() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.destroyed = true
+	s.data = record{}
+}
+
+// CSRFToken returns the synchronizer token of the session, it is generated on first use.
+func (s *Session) CSRFToken() string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.data.CSRFToken == "" {
+		s.data.CSRFToken = generateToken()
+		s.modified = true
+	}
+
+	return s.data.CSRFToken
+}
+
+// ToContext add Session to Context.
+func ToContext(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, contextKey, session)
+}
+
+// FromContext returns Session from Context.
+func FromContext(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(contextKey).(*Session)
+
+	return session, ok && session != nil
+}
+
+// Provider returns an authentication.Provider authenticating the principal logged in the session,
+// the session Handler must be applied before the authentication.Handler.
+func Provider() authentication.Provider {
+	return authentication.ProviderFunc(func(r *http.Request) (*authentication.Principal, error) {
+		session, ok := FromContext(r.Context())
+		if !ok {
+			return nil, authentication.ErrNoCredentials
+		}
+
+		principal, ok := session.Principal()
+		if !ok {
+			return nil, authentication.ErrNoCredentials
+		}
+
+		return principal, nil
+	})
+}
+
+// generateToken returns a random 256 bits token.
+func generateToken() string {
+	return base64.RawURLEncoding.EncodeToString(randomKey())
+}
+
+// randomKey returns 32 random bytes.
+func randomKey() []byte {
+	b := make([]byte, 32)
+
+	// crypto/rand.Read never returns an error.
+	_, _ = rand.Read(b)
+
+	return b
+}
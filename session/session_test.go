@@ -0,0 +1,104 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-server/authentication"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession(t *testing.T) {
+	now := time.Now()
+
+	session := newSession(now)
+	assert.True(t, session.IsNew())
+	assert.Equal(t, now, session.CreatedAt())
+	assert.Len(t, session.ID(), 43)
+	assert.False(t, session.modified)
+
+	assert.Nil(t, session.Get("foo"))
+
+	session.Set("foo", "bar")
+	assert.Equal(t, "bar", session.Get("foo"))
+	assert.True(t, session.modified)
+
+	session.Delete("foo")
+	assert.Nil(t, session.Get("foo"))
+
+	_, ok := session.Principal()
+	assert.False(t, ok)
+}
+
+func TestSessionLogin(t *testing.T) {
+	session := &Session{
+		id: "foo",
+	}
+
+	token := session.CSRFToken()
+	assert.Equal(t, token, session.CSRFToken())
+
+	session.Login(&authentication.Principal{
+		Subject: "alice",
+	})
+
+	principal, ok := session.Principal()
+	assert.True(t, ok)
+	assert.Equal(t, "alice", principal.Subject)
+
+	assert.NotEqual(t, "foo", session.ID())
+	assert.Equal(t, "foo", session.previousID)
+	assert.NotEqual(t, token, session.CSRFToken())
+
+	// The first ID is deleted after several renewals.
+	session.Renew()
+	assert.Equal(t, "foo", session.previousID)
+
+	session.Logout()
+
+	_, ok = session.Principal()
+	assert.False(t, ok)
+	assert.True(t, session.destroyed)
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	session := newSession(time.Now())
+
+	s, ok := FromContext(ToContext(context.Background(), session))
+	assert.True(t, ok)
+	assert.Same(t, session, s)
+}
+
+func TestProvider(t *testing.T) {
+	provider := Provider()
+
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+
+	_, err := provider.Authenticate(r)
+	assert.ErrorIs(t, err, authentication.ErrNoCredentials)
+
+	session := newSession(time.Now())
+
+	r = r.WithContext(ToContext(r.Context(), session))
+
+	_, err = provider.Authenticate(r)
+	assert.ErrorIs(t, err, authentication.ErrNoCredentials)
+
+	session.Login(&authentication.Principal{
+		Subject: "alice",
+	})
+
+	principal, err := provider.Authenticate(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", principal.Subject)
+}
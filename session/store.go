@@ -0,0 +1,236 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by a Store when the session does not exist or has expired.
+var ErrNotFound = errors.New("session not found")
+
+// Store interface for the server-side session data.
+//
+//go:generate mockery -case=underscore -inpkg -name=Store
+type Store interface {
+	// Load returns the data of the session or ErrNotFound.
+	Load(ctx context.Context, id string) ([]byte, error)
+	// Save stores the data of the session until expiresAt.
+	Save(ctx context.Context, id string, data []byte, expiresAt time.Time) error
+	// Delete removes the session, deleting an unknown session is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// MemoryStore is an in-memory Store, expired sessions are purged on save.
+type MemoryStore struct {
+	mtx      sync.RWMutex
+	sessions map[string]memoryEntry
+	purgedAt time.Time
+	now      func() time.Time
+}
+
+// NewMemoryStore constructor.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]memoryEntry),
+		now:      time.Now,
+	}
+}
+
+// Load implements Store.
+func (s *MemoryStore) Load(ctx context.Context, id string) ([]byte, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	entry, ok := s.sessions[id]
+	if !ok || s.now().After(entry.expiresAt) {
+		return nil, ErrNotFound
+	}
+
+	return entry.data, nil
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(ctx context.Context, id string, data []byte, expiresAt time.Time) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+
+	if now.Sub(s.purgedAt) > time.Minute {
+		for i, entry := range s.sessions {
+			if now.After(entry.expiresAt) {
+				delete(s.sessions, i)
+			}
+		}
+
+		s.purgedAt = now
+	}
+
+	s.sessions[id] = memoryEntry{
+		data:      data,
+		expiresAt: expiresAt,
+	}
+
+	return nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.sessions, id)
+
+	return nil
+}
+
+type fileEntry struct {
+	Data      []byte    `json:"data"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FileStore stores each session in a file of the directory, the file names are
+// derived from the session IDs so an ID cannot escape the directory.
+// Expired sessions are removed on load, use Purge to remove the abandoned ones.
+type FileStore struct {
+	dir string
+	now func() time.Time
+}
+
+// NewFileStore constructor, the directory is created if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	return &FileStore{
+		dir: dir,
+		now: time.Now,
+	}, nil
+}
+
+func (s *FileStore) filename(id string) string {
+	sum := sha256.Sum256([]byte(id))
+
+	return filepath.Join(s.dir, "session_"+hex.EncodeToString(sum[:]))
+}
+
+// Load implements Store.
+func (s *FileStore) Load(ctx context.Context, id string) ([]byte, error) {
+	filename := s.filename(id)
+
+	b, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	var entry fileEntry
+
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode session file: %w", err)
+	}
+
+	if s.now().After(entry.ExpiresAt) {
+		if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove session file: %w", err)
+		}
+
+		return nil, ErrNotFound
+	}
+
+	return entry.Data, nil
+}
+
+// Save implements Store, the file is replaced atomically.
+func (s *FileStore) Save(ctx context.Context, id string, data []byte, expiresAt time.Time) error {
+	b, err := json.Marshal(fileEntry{
+		Data:      data,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode session file: %w", err)
+	}
+
+	file, err := os.CreateTemp(s.dir, "tmp_")
+	if err != nil {
+		return fmt.Errorf("failed to create session file: %w", err)
+	}
+
+	if _, err := file.Write(b); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), s.filename(id)); err != nil {
+		_ = os.Remove(file.Name())
+
+		return fmt.Errorf("failed to rename session file: %w", err)
+	}
+
+	return nil
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	if err := os.Remove(s.filename(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove session file: %w", err)
+	}
+
+	return nil
+}
+
+// Purge removes the expired sessions.
+func (s *FileStore) Purge() error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "session_*"))
+	if err != nil {
+		return fmt.Errorf("failed to list session files: %w", err)
+	}
+
+	now := s.now()
+
+	for _, filename := range files {
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			continue
+		}
+
+		var entry fileEntry
+
+		if err := json.Unmarshal(b, &entry); err != nil || now.After(entry.ExpiresAt) {
+			if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to remove session file: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
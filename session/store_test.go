@@ -0,0 +1,109 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStore(t *testing.T, store Store, setNow func(now time.Time)) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	setNow(now)
+
+	_, err := store.Load(ctx, "foo")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, store.Save(ctx, "foo", []byte(`{"a":1}`), now.Add(time.Minute)))
+	assert.NoError(t, store.Save(ctx, "bar", []byte(`{"b":1}`), now.Add(time.Hour)))
+
+	b, err := store.Load(ctx, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(b))
+
+	setNow(now.Add(2 * time.Minute))
+
+	_, err = store.Load(ctx, "foo")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	b, err = store.Load(ctx, "bar")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":1}`, string(b))
+
+	assert.NoError(t, store.Delete(ctx, "bar"))
+	assert.NoError(t, store.Delete(ctx, "bar"))
+
+	_, err = store.Load(ctx, "bar")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	testStore(t, store, func(now time.Time) {
+		store.now = func() time.Time {
+			return now
+		}
+	})
+
+	assert.NoError(t, store.Save(context.Background(), "baz", []byte(`{}`), store.now().Add(time.Minute)))
+	assert.Len(t, store.sessions, 1)
+}
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sessions")
+
+	store, err := NewFileStore(dir)
+	assert.NoError(t, err)
+
+	testStore(t, store, func(now time.Time) {
+		store.now = func() time.Time {
+			return now
+		}
+	})
+
+	// The session IDs cannot escape the directory.
+	assert.NoError(t, store.Save(context.Background(), "../../foo", []byte(`{}`), store.now().Add(time.Minute)))
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestFileStorePurge(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewFileStore(dir)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	assert.NoError(t, store.Save(ctx, "foo", []byte(`{}`), now.Add(time.Minute)))
+	assert.NoError(t, store.Save(ctx, "bar", []byte(`{}`), now.Add(time.Hour)))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "session_invalid"), []byte("invalid"), 0o600))
+
+	store.now = func() time.Time {
+		return now.Add(2 * time.Minute)
+	}
+
+	assert.NoError(t, store.Purge())
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	_, err = store.Load(ctx, "bar")
+	assert.NoError(t, err)
+}